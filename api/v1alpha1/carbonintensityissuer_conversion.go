@@ -0,0 +1,197 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/rekuberate-io/carbon/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConversionDataAnnotation holds the fields of a v1beta1 object that cannot be
+// represented in v1alpha1, so that a round trip through v1alpha1 is lossless.
+const ConversionDataAnnotation = "core.rekuberate.io/conversion-data"
+
+// ConvertTo converts this CarbonIntensityIssuer to the Hub version (v1beta1).
+func (src *CarbonIntensityIssuer) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.CarbonIntensityIssuer)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	dst.Spec.ForecastRefreshInterval = hoursToDuration(src.Spec.ForecastRefreshIntervalInHours)
+	dst.Spec.LiveRefreshInterval = hoursToDuration(src.Spec.LiveRefreshIntervalInHours)
+	dst.Spec.Zone = src.Spec.Zone
+	dst.Spec.ProviderRef = src.Spec.ProviderRef.DeepCopy()
+
+	dst.Status.LastForecast = src.Status.LastForecast.DeepCopy()
+	dst.Status.LastUpdate = src.Status.LastUpdate.DeepCopy()
+	dst.Status.NextUpdate = src.Status.NextUpdate.DeepCopy()
+	dst.Status.CarbonIntensity = copyString(src.Status.CarbonIntensity)
+	dst.Status.ObservedAt = src.Status.ObservedAt.DeepCopy()
+	dst.Status.IsEstimated = copyBool(src.Status.IsEstimated)
+	dst.Status.EstimationMethod = src.Status.EstimationMethod
+	dst.Status.Conditions = copyConditions(src.Status.Conditions)
+
+	restored, err := unmarshalConversionData(&dst.ObjectMeta)
+	if err != nil || restored == nil {
+		return err
+	}
+
+	dst.Spec.EmissionsType = restored.Spec.EmissionsType
+	dst.Spec.History = restored.Spec.History
+
+	// an issuer located through v1beta1 shows its resolved zone in v1alpha1,
+	// it keeps its location as long as the zone has not been changed through
+	// v1alpha1
+	if restored.Spec.Location != nil && (src.Spec.Zone == "" || src.Spec.Zone == restored.Status.Zone) {
+		dst.Spec.Zone = ""
+		dst.Spec.Location = restored.Spec.Location
+	}
+	dst.Status.Zone = restored.Status.Zone
+	dst.Status.ZoneResolvedAt = restored.Status.ZoneResolvedAt
+	dst.Status.EmissionsType = restored.Status.EmissionsType
+	dst.Status.EmissionFactorType = restored.Status.EmissionFactorType
+	dst.Status.RenewablePercentage = restored.Status.RenewablePercentage
+	dst.Status.FossilFreePercentage = restored.Status.FossilFreePercentage
+	dst.Status.ProductionMix = restored.Status.ProductionMix
+	dst.Status.PowerBreakdownObservedAt = restored.Status.PowerBreakdownObservedAt
+	dst.Status.HistoryBackfilledAt = restored.Status.HistoryBackfilledAt
+	dst.Status.ServedBy = restored.Status.ServedBy
+
+	// intervals that are not whole hours are only restored, as long as they
+	// have not been changed in the meantime through v1alpha1
+	if durationToHours(restored.Spec.ForecastRefreshInterval) == src.Spec.ForecastRefreshIntervalInHours {
+		dst.Spec.ForecastRefreshInterval = restored.Spec.ForecastRefreshInterval
+	}
+	if durationToHours(restored.Spec.LiveRefreshInterval) == src.Spec.LiveRefreshIntervalInHours {
+		dst.Spec.LiveRefreshInterval = restored.Spec.LiveRefreshInterval
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *CarbonIntensityIssuer) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.CarbonIntensityIssuer)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	dst.Spec.ForecastRefreshIntervalInHours = durationToHours(src.Spec.ForecastRefreshInterval)
+	dst.Spec.LiveRefreshIntervalInHours = durationToHours(src.Spec.LiveRefreshInterval)
+	dst.Spec.Zone = src.Spec.Zone
+	if dst.Spec.Zone == "" {
+		// v1alpha1 has no locations, show the zone the location resolved to
+		dst.Spec.Zone = src.Status.Zone
+	}
+	dst.Spec.ProviderRef = src.Spec.ProviderRef.DeepCopy()
+
+	dst.Status.LastForecast = src.Status.LastForecast.DeepCopy()
+	dst.Status.LastUpdate = src.Status.LastUpdate.DeepCopy()
+	dst.Status.NextUpdate = src.Status.NextUpdate.DeepCopy()
+	dst.Status.CarbonIntensity = copyString(src.Status.CarbonIntensity)
+	dst.Status.ObservedAt = src.Status.ObservedAt.DeepCopy()
+	dst.Status.IsEstimated = copyBool(src.Status.IsEstimated)
+	dst.Status.EstimationMethod = src.Status.EstimationMethod
+	dst.Status.Conditions = copyConditions(src.Status.Conditions)
+
+	return marshalConversionData(src, &dst.ObjectMeta)
+}
+
+// marshalConversionData stores the spec and status of the given v1beta1
+// object in the conversion data annotation of dst.
+func marshalConversionData(src *v1beta1.CarbonIntensityIssuer, dst *metav1.ObjectMeta) error {
+	data := &v1beta1.CarbonIntensityIssuer{
+		Spec:   src.Spec,
+		Status: src.Status,
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if dst.Annotations == nil {
+		dst.Annotations = map[string]string{}
+	}
+	dst.Annotations[ConversionDataAnnotation] = string(bytes)
+
+	return nil
+}
+
+// unmarshalConversionData removes the conversion data annotation from the
+// given object and returns the v1beta1 fields it held, if any.
+func unmarshalConversionData(dst *metav1.ObjectMeta) (*v1beta1.CarbonIntensityIssuer, error) {
+	data, ok := dst.Annotations[ConversionDataAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	delete(dst.Annotations, ConversionDataAnnotation)
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+
+	restored := &v1beta1.CarbonIntensityIssuer{}
+	if err := json.Unmarshal([]byte(data), restored); err != nil {
+		return nil, err
+	}
+
+	return restored, nil
+}
+
+func hoursToDuration(hours int32) metav1.Duration {
+	return metav1.Duration{Duration: time.Duration(hours) * time.Hour}
+}
+
+func durationToHours(duration metav1.Duration) int32 {
+	return int32(math.Round(duration.Hours()))
+}
+
+func copyString(in *string) *string {
+	if in == nil {
+		return nil
+	}
+
+	out := *in
+	return &out
+}
+
+func copyBool(in *bool) *bool {
+	if in == nil {
+		return nil
+	}
+
+	out := *in
+	return &out
+}
+
+func copyConditions(in []metav1.Condition) []metav1.Condition {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]metav1.Condition, len(in))
+	for i := range in {
+		in[i].DeepCopyInto(&out[i])
+	}
+
+	return out
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rekuberate-io/carbon/api/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCarbonIntensityIssuerConversion(t *testing.T) {
+	carbonIntensity := "123.45"
+
+	alpha := &CarbonIntensityIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "eu-de", Namespace: "default"},
+		Spec: CarbonIntensityIssuerSpec{
+			ForecastRefreshIntervalInHours: 12,
+			LiveRefreshIntervalInHours:     1,
+			Zone:                           "DE",
+			ProviderRef:                    &v1.ObjectReference{Kind: "Simulator", Name: "simulator-sample"},
+		},
+		Status: CarbonIntensityIssuerStatus{CarbonIntensity: &carbonIntensity},
+	}
+
+	beta := &v1beta1.CarbonIntensityIssuer{}
+	if err := alpha.ConvertTo(beta); err != nil {
+		t.Fatal(err)
+	}
+
+	if beta.Spec.ForecastRefreshInterval.Duration != 12*time.Hour {
+		t.Errorf("forecastRefreshInterval = %s, want 12h", beta.Spec.ForecastRefreshInterval.Duration)
+	}
+	if beta.Spec.LiveRefreshInterval.Duration != time.Hour {
+		t.Errorf("liveRefreshInterval = %s, want 1h", beta.Spec.LiveRefreshInterval.Duration)
+	}
+
+	roundTripped := &CarbonIntensityIssuer{}
+	if err := roundTripped.ConvertFrom(beta); err != nil {
+		t.Fatal(err)
+	}
+	delete(roundTripped.Annotations, ConversionDataAnnotation)
+	if len(roundTripped.Annotations) == 0 {
+		roundTripped.Annotations = nil
+	}
+
+	if !reflect.DeepEqual(alpha, roundTripped) {
+		t.Errorf("v1alpha1 round trip mismatch:\n got: %+v\nwant: %+v", roundTripped, alpha)
+	}
+}
+
+func TestCarbonIntensityIssuerConversionPreservesDurations(t *testing.T) {
+	beta := &v1beta1.CarbonIntensityIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "eu-de", Namespace: "default"},
+		Spec: v1beta1.CarbonIntensityIssuerSpec{
+			ForecastRefreshInterval: metav1.Duration{Duration: 90 * time.Minute},
+			LiveRefreshInterval:     metav1.Duration{Duration: 5 * time.Minute},
+			Zone:                    "DE",
+			ProviderRef:             &v1.ObjectReference{Kind: "Simulator", Name: "simulator-sample"},
+		},
+	}
+
+	alpha := &CarbonIntensityIssuer{}
+	if err := alpha.ConvertFrom(beta); err != nil {
+		t.Fatal(err)
+	}
+
+	if alpha.Spec.ForecastRefreshIntervalInHours != 2 {
+		t.Errorf("forecastRefreshIntervalHours = %d, want 2", alpha.Spec.ForecastRefreshIntervalInHours)
+	}
+
+	roundTripped := &v1beta1.CarbonIntensityIssuer{}
+	if err := alpha.ConvertTo(roundTripped); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(beta, roundTripped) {
+		t.Errorf("v1beta1 round trip mismatch:\n got: %+v\nwant: %+v", roundTripped, beta)
+	}
+
+	// changes made through v1alpha1 take precedence over the preserved durations
+	if err := alpha.ConvertFrom(beta); err != nil {
+		t.Fatal(err)
+	}
+	alpha.Spec.LiveRefreshIntervalInHours = 3
+
+	if err := alpha.ConvertTo(roundTripped); err != nil {
+		t.Fatal(err)
+	}
+
+	if roundTripped.Spec.LiveRefreshInterval.Duration != 3*time.Hour {
+		t.Errorf("liveRefreshInterval = %s, want 3h", roundTripped.Spec.LiveRefreshInterval.Duration)
+	}
+	if roundTripped.Spec.ForecastRefreshInterval.Duration != 90*time.Minute {
+		t.Errorf("forecastRefreshInterval = %s, want 1h30m", roundTripped.Spec.ForecastRefreshInterval.Duration)
+	}
+}
+
+func TestCarbonIntensityIssuerConversionPreservesLocation(t *testing.T) {
+	resolvedAt := metav1.NewTime(time.Date(2023, 6, 1, 12, 0, 0, 0, time.Local))
+	beta := &v1beta1.CarbonIntensityIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "caiso-north", Namespace: "default"},
+		Spec: v1beta1.CarbonIntensityIssuerSpec{
+			ForecastRefreshInterval: metav1.Duration{Duration: 12 * time.Hour},
+			LiveRefreshInterval:     metav1.Duration{Duration: time.Hour},
+			Location: &v1beta1.Location{
+				Latitude:        "37.7749",
+				Longitude:       "-122.4194",
+				RefreshInterval: &metav1.Duration{Duration: 24 * time.Hour},
+			},
+			ProviderRef: &v1.ObjectReference{Kind: "WattTime", Name: "watttime-sample"},
+		},
+		Status: v1beta1.CarbonIntensityIssuerStatus{
+			Zone:           "CAISO_NORTH",
+			ZoneResolvedAt: &resolvedAt,
+		},
+	}
+
+	alpha := &CarbonIntensityIssuer{}
+	if err := alpha.ConvertFrom(beta); err != nil {
+		t.Fatal(err)
+	}
+
+	roundTripped := &v1beta1.CarbonIntensityIssuer{}
+	if err := alpha.ConvertTo(roundTripped); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(beta, roundTripped) {
+		t.Errorf("v1beta1 round trip mismatch:\n got: %+v\nwant: %+v", roundTripped, beta)
+	}
+}
+
+func TestCarbonIntensityIssuerConversionFillsResolvedZone(t *testing.T) {
+	location := &v1beta1.Location{Latitude: "37.7749", Longitude: "-122.4194"}
+	tests := []struct {
+		name         string
+		resolvedZone string
+		// zone is set through v1alpha1 before converting back, if not empty
+		zone string
+		want string
+	}{
+		{name: "resolved location", resolvedZone: "CAISO_NORTH", want: "CAISO_NORTH"},
+		{name: "unresolved location", want: ""},
+		{name: "zone changed through v1alpha1", resolvedZone: "CAISO_NORTH", zone: "BPA", want: "CAISO_NORTH"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			beta := &v1beta1.CarbonIntensityIssuer{
+				ObjectMeta: metav1.ObjectMeta{Name: "san-francisco", Namespace: "default"},
+				Spec: v1beta1.CarbonIntensityIssuerSpec{
+					ForecastRefreshInterval: metav1.Duration{Duration: 12 * time.Hour},
+					LiveRefreshInterval:     metav1.Duration{Duration: time.Hour},
+					Location:                location,
+					ProviderRef:             &v1.ObjectReference{Kind: "WattTime", Name: "watttime-sample"},
+				},
+				Status: v1beta1.CarbonIntensityIssuerStatus{Zone: tt.resolvedZone},
+			}
+
+			alpha := &CarbonIntensityIssuer{}
+			if err := alpha.ConvertFrom(beta); err != nil {
+				t.Fatal(err)
+			}
+
+			if alpha.Spec.Zone != tt.want {
+				t.Errorf("zone = %q, want %q", alpha.Spec.Zone, tt.want)
+			}
+
+			if tt.zone != "" {
+				alpha.Spec.Zone = tt.zone
+			}
+
+			roundTripped := &v1beta1.CarbonIntensityIssuer{}
+			if err := alpha.ConvertTo(roundTripped); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.zone != "" {
+				if roundTripped.Spec.Zone != tt.zone || roundTripped.Spec.Location != nil {
+					t.Errorf("zone = %q at %v, want the zone set through v1alpha1 instead of the location", roundTripped.Spec.Zone, roundTripped.Spec.Location)
+				}
+				return
+			}
+
+			if roundTripped.Spec.Zone != "" || !reflect.DeepEqual(roundTripped.Spec.Location, location) {
+				t.Errorf("zone = %q at %v, want the location only", roundTripped.Spec.Zone, roundTripped.Spec.Location)
+			}
+		})
+	}
+}
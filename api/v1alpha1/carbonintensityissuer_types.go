@@ -62,6 +62,15 @@ type CarbonIntensityIssuerStatus struct {
 	NextUpdate      *metav1.Time `json:"nextUpdate,omitempty"`
 	CarbonIntensity *string      `json:"carbonIntensity,omitempty"`
 
+	// ObservedAt is the time the provider reported the carbon intensity for
+	ObservedAt *metav1.Time `json:"observedAt,omitempty"`
+	// IsEstimated is true when the provider reported an estimated rather than a
+	// measured carbon intensity
+	IsEstimated *bool `json:"isEstimated,omitempty"`
+	// EstimationMethod is the method the provider used to estimate the carbon
+	// intensity, if any
+	EstimationMethod string `json:"estimationMethod,omitempty"`
+
 	// Conditions store the status conditions of the Memcached instances
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
@@ -69,6 +78,7 @@ type CarbonIntensityIssuerStatus struct {
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:deprecatedversion:warning="core.rekuberate.io/v1alpha1 CarbonIntensityIssuer is deprecated, use core.rekuberate.io/v1beta1"
 
 // CarbonIntensityIssuer is the Schema for the carbonintensityissuers API
 // +kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.spec.providerRef.name`
@@ -76,6 +86,8 @@ type CarbonIntensityIssuerStatus struct {
 // +kubebuilder:printcolumn:name="Forecast INVL(h)",type=string,JSONPath=`.spec.forecastRefreshIntervalHours`
 // +kubebuilder:printcolumn:name="Last Forecast",type=string,JSONPath=`.status.lastForecast`
 // +kubebuilder:printcolumn:name="CI (gCO2eq/KWh)",type=string,JSONPath=`.status.carbonIntensity`
+// +kubebuilder:printcolumn:name="Observed At",type=string,JSONPath=`.status.observedAt`,priority=1
+// +kubebuilder:printcolumn:name="Estimated",type=boolean,JSONPath=`.status.isEstimated`,priority=1
 // +kubebuilder:printcolumn:name="Last Update",type=string,JSONPath=`.status.lastUpdate`
 // +kubebuilder:printcolumn:name="Next Update",type=string,JSONPath=`.status.nextUpdate`
 type CarbonIntensityIssuer struct {
@@ -0,0 +1,116 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CompositeMode is how a CompositeProvider combines its members
+// +kubebuilder:validation:Enum=failover;ensemble;weightedBlend
+type CompositeMode string
+
+const (
+	// Failover serves the first member, in order, that delivers
+	Failover CompositeMode = "failover"
+	// Ensemble serves the median or mean of all members that deliver
+	Ensemble CompositeMode = "ensemble"
+	// WeightedBlend serves the weighted mean of all members that deliver,
+	// e.g. for a site that is fed by several grid zones
+	WeightedBlend CompositeMode = "weightedBlend"
+)
+
+// CompositeAggregation is how an ensemble combines the values of its members
+// +kubebuilder:validation:Enum=median;mean
+type CompositeAggregation string
+
+const (
+	Median CompositeAggregation = "median"
+	Mean   CompositeAggregation = "mean"
+)
+
+// CompositeProviderMember is a provider object the CompositeProvider draws
+// from
+type CompositeProviderMember struct {
+	// ProviderRef references the provider object, which defaults to the
+	// namespace of the CompositeProvider
+	// +kubebuilder:validation:Required
+	ProviderRef *v1.ObjectReference `json:"providerRef"`
+
+	// Zone the member is queried for instead of the zone of the issuer, e.g.
+	// the grid zones of a site that is fed by several of them
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// Weight of the member in a weighted blend, relative to the weights of the
+	// other members
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default:=1
+	// +optional
+	Weight int32 `json:"weight,omitempty"`
+}
+
+// CompositeProviderSpec defines the desired state of CompositeProvider
+type CompositeProviderSpec struct {
+	// +kubebuilder:default:=failover
+	Mode CompositeMode `json:"mode,omitempty"`
+
+	// Aggregation combines the members of an ensemble
+	// +kubebuilder:default:=median
+	Aggregation CompositeAggregation `json:"aggregation,omitempty"`
+
+	// Members are the provider objects, in the order they are tried in
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Members []CompositeProviderMember `json:"members"`
+}
+
+// CompositeProviderStatus defines the observed state of CompositeProvider
+type CompositeProviderStatus struct {
+	ProviderStatus `json:",inline"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// CompositeProvider is the Schema for the compositeproviders API. It combines
+// other provider objects, to fail over between them, or to serve an ensemble
+// or a weighted blend of their values.
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=`.spec.mode`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Last Contact",type=string,JSONPath=`.status.lastContact`
+type CompositeProvider struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CompositeProviderSpec   `json:"spec,omitempty"`
+	Status CompositeProviderStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CompositeProviderList contains a list of CompositeProvider
+type CompositeProviderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CompositeProvider `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CompositeProvider{}, &CompositeProviderList{})
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupWebhookWithManager registers the webhooks of CompositeProvider.
+// Validation looks up the members of the provider, so the validator is passed
+// in by the caller.
+func (r *CompositeProvider) SetupWebhookWithManager(mgr ctrl.Manager, validator admission.CustomValidator) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithValidator(validator).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-core-rekuberate-io-v1alpha1-compositeprovider,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.rekuberate.io,resources=compositeproviders,verbs=create;update,versions=v1alpha1,name=vcompositeprovider.kb.io,admissionReviewVersions=v1
@@ -0,0 +1,83 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EIASpec defines the desired state of EIA
+type EIASpec struct {
+	// Endpoint is the URL of the EIA Open Data API, it defaults to
+	// https://api.eia.gov/v2
+	// +optional
+	Endpoint *string `json:"endpoint,omitempty"`
+
+	// ApiKeyRef references the Secret holding the API key of the EIA Open
+	// Data API in the key apiKey
+	// +kubebuilder:validation:Required
+	ApiKeyRef *v1.SecretReference `json:"apiKeyRef"`
+
+	// EmissionFactorSetRef references the EmissionFactorSet the fuel types
+	// take their emission factors from; the lifecycle factors of the IPCC AR5
+	// apply without it
+	// +optional
+	EmissionFactorSetRef *v1.LocalObjectReference `json:"emissionFactorSetRef,omitempty"`
+
+	// EmissionFactors override the emission factors of single fuel types,
+	// e.g. coal or naturalGas
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k in ['coal', 'naturalGas', 'nuclear', 'oil', 'hydro', 'solar', 'wind', 'geothermal', 'battery', 'pumpedStorage', 'solarWithBattery', 'other', 'unknown'])",message="unknown fuel type"
+	// +optional
+	EmissionFactors map[string]EmissionFactor `json:"emissionFactors,omitempty"`
+}
+
+// EIAStatus defines the observed state of EIA
+type EIAStatus struct {
+	ProviderStatus `json:",inline"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=eias
+
+// EIA is the Schema for the eias API. It computes average carbon intensities
+// from the hourly generation per fuel type of US balancing authorities, as the
+// EIA Hourly Electric Grid Monitor publishes it; issuers use EIA codes such
+// as CISO or PJM, or the balancing authority abbreviations of WattTime.
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Last Contact",type=string,JSONPath=`.status.lastContact`
+type EIA struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EIASpec   `json:"spec,omitempty"`
+	Status EIAStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// EIAList contains a list of EIA
+type EIAList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EIA `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EIA{}, &EIAList{})
+}
@@ -31,12 +31,17 @@ type ElectricityMapsSpec struct {
 	Subscription            string              `json:"subscription"`
 	CommercialTrialEndpoint *string             `json:"commercialTrialEndpoint,omitempty"`
 	ApiKeyRef               *v1.SecretReference `json:"apiKeyRef"`
+
+	// EmissionFactorType selects whether carbon intensities are based on
+	// lifecycle or direct (operational) emission factors
+	// +kubebuilder:validation:Enum=lifecycle;direct
+	// +kubebuilder:default:=lifecycle
+	EmissionFactorType string `json:"emissionFactorType,omitempty"`
 }
 
 // ElectricityMapsStatus defines the observed state of ElectricityMaps
 type ElectricityMapsStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	ProviderStatus `json:",inline"`
 }
 
 //+kubebuilder:object:root=true
@@ -44,6 +49,9 @@ type ElectricityMapsStatus struct {
 
 // ElectricityMaps is the Schema for the electricitymaps API
 // +kubebuilder:printcolumn:name="Subscription",type=string,JSONPath=`.spec.subscription`
+// +kubebuilder:printcolumn:name="Emission Factors",type=string,JSONPath=`.spec.emissionFactorType`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Last Contact",type=string,JSONPath=`.status.lastContact`
 type ElectricityMaps struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
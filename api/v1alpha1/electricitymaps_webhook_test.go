@@ -0,0 +1,61 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestElectricityMapsValidate(t *testing.T) {
+	endpoint := func(s string) *string { return &s }
+	apiKeyRef := &v1.SecretReference{Name: "electricitymaps-api-key"}
+
+	tests := []struct {
+		name    string
+		spec    ElectricityMapsSpec
+		wantErr bool
+	}{
+		{name: "free tier", spec: ElectricityMapsSpec{Subscription: "free_tier", ApiKeyRef: apiKeyRef}},
+		{name: "commercial trial", spec: ElectricityMapsSpec{Subscription: "commercial_trial", CommercialTrialEndpoint: endpoint("/2w97h07rvxvuaa1g"), ApiKeyRef: apiKeyRef}},
+		{name: "commercial trial without endpoint", spec: ElectricityMapsSpec{Subscription: "commercial_trial", ApiKeyRef: apiKeyRef}, wantErr: true},
+		{name: "commercial trial with empty endpoint", spec: ElectricityMapsSpec{Subscription: "commercial_trial", CommercialTrialEndpoint: endpoint(""), ApiKeyRef: apiKeyRef}, wantErr: true},
+		{name: "commercial trial with absolute endpoint", spec: ElectricityMapsSpec{Subscription: "commercial_trial", CommercialTrialEndpoint: endpoint("https://api-access.electricitymaps.com/2w97h07rvxvuaa1g"), ApiKeyRef: apiKeyRef}, wantErr: true},
+		{name: "commercial trial with endpoint of another host", spec: ElectricityMapsSpec{Subscription: "commercial_trial", CommercialTrialEndpoint: endpoint("//example.com/2w97h07rvxvuaa1g"), ApiKeyRef: apiKeyRef}, wantErr: true},
+		{name: "missing secret reference", spec: ElectricityMapsSpec{Subscription: "free_tier"}, wantErr: true},
+		{name: "secret reference without name", spec: ElectricityMapsSpec{Subscription: "free_tier", ApiKeyRef: &v1.SecretReference{Namespace: "default"}}, wantErr: true},
+		{name: "malformed secret name", spec: ElectricityMapsSpec{Subscription: "free_tier", ApiKeyRef: &v1.SecretReference{Name: "API_Key"}}, wantErr: true},
+		{name: "malformed secret namespace", spec: ElectricityMapsSpec{Subscription: "free_tier", ApiKeyRef: &v1.SecretReference{Name: "api-key", Namespace: "carbon.system"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ElectricityMaps{Spec: tt.spec}
+
+			err := r.ValidateCreate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil && !apierrors.IsInvalid(err) {
+				t.Errorf("error = %v, want an Invalid status error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EmissionFactor is the carbon intensity of a fuel or production type in
+// gCO2eq/kWh
+// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+type EmissionFactor string
+
+// EmissionFactorType is the scope of emissions an emission factor covers
+// +kubebuilder:validation:Enum=lifecycle;direct
+type EmissionFactorType string
+
+const (
+	// Lifecycle factors cover the emissions of the whole lifecycle of a power
+	// plant, including construction, fuel supply and decommissioning
+	Lifecycle EmissionFactorType = "lifecycle"
+	// Direct factors cover the emissions of the combustion only
+	Direct EmissionFactorType = "direct"
+)
+
+// ZoneEmissionFactors override the emission factors of fuels within a zone
+type ZoneEmissionFactors struct {
+	// Zone the factors apply to, as the providers resolve the zone of an
+	// issuer: the EIC code of the area for ENTSOE (e.g. 10YNL----------L),
+	// the code of the balancing authority for EIA (e.g. CISO, also for the
+	// WattTime region CAISO_NORTH)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Zone string `json:"zone"`
+
+	// Factors map fuels to their emission factors in gCO2eq/kWh
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k in ['coal', 'lignite', 'gas', 'oil', 'peat', 'nuclear', 'hydro', 'wind', 'solar', 'geothermal', 'biomass', 'marine', 'waste', 'otherRenewable', 'storage', 'other'])",message="unknown fuel"
+	// +kubebuilder:validation:Required
+	Factors map[string]EmissionFactor `json:"factors"`
+}
+
+// EmissionFactorSetSpec defines the desired state of EmissionFactorSet
+// +kubebuilder:validation:XValidation:rule="self.useDefaults || (has(self.factors) && 'other' in self.factors)",message="factors must include other if the defaults are not used"
+type EmissionFactorSetSpec struct {
+	// Type of the emission factors, lifecycle or direct
+	// +kubebuilder:default=lifecycle
+	// +optional
+	Type EmissionFactorType `json:"type,omitempty"`
+
+	// UseDefaults includes the built-in factors of the IPCC AR5 for the type;
+	// factors in the spec take precedence over them
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Type=boolean
+	// +optional
+	UseDefaults *bool `json:"useDefaults,omitempty"`
+
+	// Factors map fuels to their emission factors in gCO2eq/kWh, generation
+	// of fuels without a factor counts as other
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k in ['coal', 'lignite', 'gas', 'oil', 'peat', 'nuclear', 'hydro', 'wind', 'solar', 'geothermal', 'biomass', 'marine', 'waste', 'otherRenewable', 'storage', 'other'])",message="unknown fuel"
+	// +optional
+	Factors map[string]EmissionFactor `json:"factors,omitempty"`
+
+	// Zones override the factors within single zones
+	// +listType=map
+	// +listMapKey=zone
+	// +optional
+	Zones []ZoneEmissionFactors `json:"zones,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+
+// EmissionFactorSet is the Schema for the emissionfactorsets API. It holds the
+// emission factors providers compute carbon intensities from the generation
+// per fuel with, so that the factors behind every reported number can be
+// audited and tuned in one place.
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+// +kubebuilder:printcolumn:name="Defaults",type=boolean,JSONPath=`.spec.useDefaults`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type EmissionFactorSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec EmissionFactorSetSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// EmissionFactorSetList contains a list of EmissionFactorSet
+type EmissionFactorSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EmissionFactorSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EmissionFactorSet{}, &EmissionFactorSetList{})
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ENTSOESpec defines the desired state of ENTSOE
+type ENTSOESpec struct {
+	// Endpoint is the URL of the Transparency Platform API, it defaults to
+	// https://web-api.tp.entsoe.eu/api
+	// +optional
+	Endpoint *string `json:"endpoint,omitempty"`
+
+	// SecurityTokenRef references the Secret holding the security token of
+	// the Transparency Platform in the key securityToken
+	// +kubebuilder:validation:Required
+	SecurityTokenRef *v1.SecretReference `json:"securityTokenRef"`
+
+	// EmissionFactorSetRef references the EmissionFactorSet the production
+	// types take the emission factors of their fuels from; the lifecycle
+	// factors of the IPCC AR5 apply without it
+	// +optional
+	EmissionFactorSetRef *v1.LocalObjectReference `json:"emissionFactorSetRef,omitempty"`
+
+	// EmissionFactors override the emission factors of single production
+	// types, e.g. gas or hardCoal
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k in ['biomass', 'lignite', 'coalDerivedGas', 'gas', 'hardCoal', 'oil', 'oilShale', 'peat', 'geothermal', 'hydroPumpedStorage', 'hydroRunOfRiver', 'hydroReservoir', 'marine', 'nuclear', 'otherRenewable', 'solar', 'waste', 'windOffshore', 'windOnshore', 'other', 'energyStorage'])",message="unknown production type"
+	// +optional
+	EmissionFactors map[string]EmissionFactor `json:"emissionFactors,omitempty"`
+}
+
+// ENTSOEStatus defines the observed state of ENTSOE
+type ENTSOEStatus struct {
+	ProviderStatus `json:",inline"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ENTSOE is the Schema for the entsoes API. It computes carbon intensities
+// from the generation per production type published on the ENTSO-E
+// Transparency Platform; issuers use zone keys such as DE or SE-SE3, or the
+// EIC code of a bidding zone or control area.
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Last Contact",type=string,JSONPath=`.status.lastContact`
+type ENTSOE struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ENTSOESpec   `json:"spec,omitempty"`
+	Status ENTSOEStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ENTSOEList contains a list of ENTSOE
+type ENTSOEList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ENTSOE `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ENTSOE{}, &ENTSOEList{})
+}
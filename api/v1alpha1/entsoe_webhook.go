@@ -0,0 +1,71 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"net/url"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+func (r *ENTSOE) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-core-rekuberate-io-v1alpha1-entsoe,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.rekuberate.io,resources=entsoes,verbs=create;update,versions=v1alpha1,name=ventsoe.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &ENTSOE{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *ENTSOE) ValidateCreate() error {
+	return r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *ENTSOE) ValidateUpdate(old runtime.Object) error {
+	return r.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *ENTSOE) ValidateDelete() error {
+	return nil
+}
+
+func (r *ENTSOE) validate() error {
+	var allErrs field.ErrorList
+
+	if endpoint := r.Spec.Endpoint; endpoint != nil {
+		endpointPath := field.NewPath("spec", "endpoint")
+		if u, err := url.Parse(*endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(endpointPath, *endpoint, "must be an absolute URL"))
+		}
+	}
+
+	allErrs = append(allErrs, ValidateSecretReference(r.Spec.SecurityTokenRef, field.NewPath("spec", "securityTokenRef"))...)
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(GroupVersion.WithKind("ENTSOE").GroupKind(), r.Name, allErrs)
+}
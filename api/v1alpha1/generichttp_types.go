@@ -0,0 +1,163 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GenericHTTPExpression extracts a value from a JSON document, either with a
+// JSONPath expression, e.g. {.data.carbonIntensity}, or with a CEL expression,
+// e.g. self.data.carbonIntensity, where self is the document.
+// +kubebuilder:validation:XValidation:rule="has(self.jsonPath) != has(self.cel)",message="exactly one of jsonPath or cel must be set"
+type GenericHTTPExpression struct {
+	// +optional
+	JSONPath string `json:"jsonPath,omitempty"`
+	// +optional
+	CEL string `json:"cel,omitempty"`
+}
+
+// GenericHTTPAuth adds credentials from a Secret to every request
+type GenericHTTPAuth struct {
+	// +kubebuilder:validation:Required
+	SecretRef *v1.SecretReference `json:"secretRef"`
+
+	// Headers maps request header names to the keys of the Secret holding
+	// their values
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// QueryParameters maps query parameter names to the keys of the Secret
+	// holding their values
+	// +optional
+	QueryParameters map[string]string `json:"queryParameters,omitempty"`
+}
+
+// GenericHTTPCurrent extracts the current carbon intensity from the response
+// of its URL
+type GenericHTTPCurrent struct {
+	// URL returns the current carbon intensity; {zone} is replaced by the zone
+	// of the issuer
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+
+	// +kubebuilder:validation:Required
+	Value GenericHTTPExpression `json:"value"`
+
+	// Timestamp of the value, either RFC 3339 or seconds since the epoch; the
+	// time of the request is used if it is not set
+	// +optional
+	Timestamp *GenericHTTPExpression `json:"timestamp,omitempty"`
+}
+
+// GenericHTTPForecast extracts the forecast from the response of its URL
+type GenericHTTPForecast struct {
+	// URL returns the forecast; {zone} is replaced by the zone of the issuer
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+
+	// Points selects the array of forecast points of the response, either
+	// the array itself, e.g. {.forecast}, or its elements, e.g.
+	// {.forecast[*]}; points that are arrays themselves must be selected by
+	// their array
+	// +kubebuilder:validation:Required
+	Points GenericHTTPExpression `json:"points"`
+
+	// Value is evaluated against every point, which is self in CEL expressions
+	// +kubebuilder:validation:Required
+	Value GenericHTTPExpression `json:"value"`
+
+	// Timestamp is evaluated against every point, either RFC 3339 or seconds
+	// since the epoch
+	// +kubebuilder:validation:Required
+	Timestamp GenericHTTPExpression `json:"timestamp"`
+
+	// Horizon is the time span the forecast usually covers
+	// +kubebuilder:default="24h"
+	// +kubebuilder:validation:Type=string
+	// +optional
+	Horizon *metav1.Duration `json:"horizon,omitempty"`
+}
+
+// GenericHTTPSpec defines the desired state of GenericHTTP
+type GenericHTTPSpec struct {
+	// +kubebuilder:validation:Required
+	Current GenericHTTPCurrent `json:"current"`
+
+	// Forecast is optional, without it the provider does not deliver forecasts
+	// +optional
+	Forecast *GenericHTTPForecast `json:"forecast,omitempty"`
+
+	// +optional
+	Auth *GenericHTTPAuth `json:"auth,omitempty"`
+
+	// ConversionFactor converts the extracted values to gCO2eq/kWh, e.g.
+	// 0.45359237 for lbs/MWh
+	// +kubebuilder:default="1"
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`
+	ConversionFactor string `json:"conversionFactor,omitempty"`
+
+	// +kubebuilder:validation:Enum=average;marginal
+	// +kubebuilder:default:=average
+	EmissionsType string `json:"emissionsType,omitempty"`
+
+	// Resolution is the interval of the data points of the service
+	// +kubebuilder:default="1h"
+	// +kubebuilder:validation:Type=string
+	// +optional
+	Resolution *metav1.Duration `json:"resolution,omitempty"`
+
+	// Zones the service delivers carbon intensities for; any zone is accepted
+	// if it is empty
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+}
+
+// GenericHTTPStatus defines the observed state of GenericHTTP
+type GenericHTTPStatus struct {
+	ProviderStatus `json:",inline"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// GenericHTTP is the Schema for the generichttps API. It delivers carbon
+// intensities from any HTTP service with JSON responses.
+// +kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.spec.current.url`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Last Contact",type=string,JSONPath=`.status.lastContact`
+type GenericHTTP struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GenericHTTPSpec   `json:"spec,omitempty"`
+	Status GenericHTTPStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// GenericHTTPList contains a list of GenericHTTP
+type GenericHTTPList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GenericHTTP `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GenericHTTP{}, &GenericHTTPList{})
+}
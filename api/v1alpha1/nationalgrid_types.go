@@ -0,0 +1,65 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NationalGridSpec defines the desired state of NationalGrid
+type NationalGridSpec struct {
+	// Endpoint is the base URL of the Carbon Intensity API, it defaults to
+	// https://api.carbonintensity.org.uk/
+	// +optional
+	Endpoint *string `json:"endpoint,omitempty"`
+}
+
+// NationalGridStatus defines the observed state of NationalGrid
+type NationalGridStatus struct {
+	ProviderStatus `json:",inline"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// NationalGrid is the Schema for the nationalgrids API. It delivers the
+// carbon intensities of Great Britain published by the National Grid ESO;
+// issuers use GB as zone for the national intensity, a region ID (1-17) for a
+// regional one, or the outward code of a postcode, e.g. RG10.
+// +kubebuilder:printcolumn:name="Endpoint",type=string,JSONPath=`.spec.endpoint`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Last Contact",type=string,JSONPath=`.status.lastContact`
+type NationalGrid struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NationalGridSpec   `json:"spec,omitempty"`
+	Status NationalGridStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NationalGridList contains a list of NationalGrid
+type NationalGridList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NationalGrid `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NationalGrid{}, &NationalGridList{})
+}
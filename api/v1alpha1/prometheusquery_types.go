@@ -0,0 +1,124 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PrometheusQueryAuth authenticates the queries with credentials from a
+// Secret; bearer tokens are read from the key token, basic auth from the keys
+// username and password.
+type PrometheusQueryAuth struct {
+	// +kubebuilder:validation:Enum=bearer;basic
+	// +kubebuilder:default:=bearer
+	Type string `json:"type,omitempty"`
+
+	// +kubebuilder:validation:Required
+	SecretRef *v1.SecretReference `json:"secretRef"`
+}
+
+// PrometheusQueryForecast selects the forecast with an instant query that
+// returns one series per forecast point
+type PrometheusQueryForecast struct {
+	// Query returns the forecast points; {zone} is replaced by the zone of the
+	// issuer
+	// +kubebuilder:validation:MinLength=1
+	Query string `json:"query"`
+
+	// TimeLabel is the label of the series holding the time of the forecast
+	// point, either RFC 3339 or seconds since the epoch
+	// +kubebuilder:default:=point_time
+	TimeLabel string `json:"timeLabel,omitempty"`
+
+	// Horizon is the time span the forecast usually covers
+	// +kubebuilder:default="24h"
+	// +kubebuilder:validation:Type=string
+	// +optional
+	Horizon *metav1.Duration `json:"horizon,omitempty"`
+}
+
+// PrometheusQuerySpec defines the desired state of PrometheusQuery
+type PrometheusQuerySpec struct {
+	// Address of the Prometheus compatible query API, e.g.
+	// http://prometheus.monitoring:9090
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+
+	// Query returns the current carbon intensity as a scalar or as a vector of
+	// a single series; {zone} is replaced by the zone of the issuer
+	// +kubebuilder:validation:MinLength=1
+	Query string `json:"query"`
+
+	// Forecast is optional, without it the provider does not deliver forecasts
+	// +optional
+	Forecast *PrometheusQueryForecast `json:"forecast,omitempty"`
+
+	// +optional
+	Auth *PrometheusQueryAuth `json:"auth,omitempty"`
+
+	// +kubebuilder:validation:Enum=average;marginal
+	// +kubebuilder:default:=average
+	EmissionsType string `json:"emissionsType,omitempty"`
+
+	// Resolution is the interval the queried series change in
+	// +kubebuilder:default="5m"
+	// +kubebuilder:validation:Type=string
+	// +optional
+	Resolution *metav1.Duration `json:"resolution,omitempty"`
+
+	// Zones the queries deliver carbon intensities for; any zone is accepted
+	// if it is empty
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+}
+
+// PrometheusQueryStatus defines the observed state of PrometheusQuery
+type PrometheusQueryStatus struct {
+	ProviderStatus `json:",inline"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// PrometheusQuery is the Schema for the prometheusqueries API. It delivers
+// carbon intensities from PromQL queries against a Prometheus compatible
+// endpoint.
+// +kubebuilder:printcolumn:name="Address",type=string,JSONPath=`.spec.address`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Last Contact",type=string,JSONPath=`.status.lastContact`
+type PrometheusQuery struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PrometheusQuerySpec   `json:"spec,omitempty"`
+	Status PrometheusQueryStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// PrometheusQueryList contains a list of PrometheusQuery
+type PrometheusQueryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PrometheusQuery `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PrometheusQuery{}, &PrometheusQueryList{})
+}
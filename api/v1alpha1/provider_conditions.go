@@ -0,0 +1,40 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ProviderPending  = "ProviderPending"
+	ProviderReady    = "ProviderReady"
+	ProviderNotReady = "ProviderNotReady"
+
+	CredentialsPending     = "CredentialsPending"
+	CredentialsValid       = "CredentialsValid"
+	CredentialsInvalid     = "CredentialsInvalid"
+	CredentialsCheckFailed = "CredentialsCheckFailed"
+	CredentialsNotRequired = "CredentialsNotRequired"
+)
+
+var (
+	ConditionReady = metav1.Condition{
+		Type:   "Ready",
+		Status: metav1.ConditionUnknown,
+		Reason: ProviderPending,
+	}
+
+	ConditionCredentialsValid = metav1.Condition{
+		Type:   "CredentialsValid",
+		Status: metav1.ConditionUnknown,
+		Reason: CredentialsPending,
+	}
+)
+
+func GetProviderConditions() []metav1.Condition {
+	conditions := []metav1.Condition{
+		ConditionReady,
+		ConditionCredentialsValid,
+	}
+
+	return conditions
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ProviderStatus is the observed state shared by all provider kinds
+type ProviderStatus struct {
+	// LastContact is the time the provider was last reached successfully
+	LastContact *metav1.Time `json:"lastContact,omitempty"`
+	// LastCheck is the time the provider was last checked
+	LastCheck *metav1.Time `json:"lastCheck,omitempty"`
+	// ErrorCount is the number of failed checks of the provider
+	ErrorCount int32 `json:"errorCount,omitempty"`
+	// ConsecutiveErrors is the number of failed checks since the last
+	// successful contact
+	ConsecutiveErrors int32 `json:"consecutiveErrors,omitempty"`
+	// LastError is the error of the last failed check
+	LastError string `json:"lastError,omitempty"`
+	// DependentIssuers are the issuers referencing the provider, as
+	// namespace/name
+	DependentIssuers []string `json:"dependentIssuers,omitempty"`
+
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
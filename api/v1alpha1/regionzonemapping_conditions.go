@@ -0,0 +1,28 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	RegionsPending   = "RegionsPending"
+	RegionsMapped    = "RegionsMapped"
+	RegionsUnmapped  = "RegionsUnmapped"
+	RegionsSyncError = "RegionsSyncError"
+)
+
+var (
+	ConditionRegionsMapped = metav1.Condition{
+		Type:   "RegionsMapped",
+		Status: metav1.ConditionUnknown,
+		Reason: RegionsPending,
+	}
+)
+
+func GetRegionZoneMappingConditions() []metav1.Condition {
+	conditions := []metav1.Condition{
+		ConditionRegionsMapped,
+	}
+
+	return conditions
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RegionMapping maps the value of a node region label to a provider zone
+type RegionMapping struct {
+	// Region is the value of the region label of the nodes
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Region string `json:"region"`
+
+	// Zone is the provider zone the region lies in
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Zone string `json:"zone"`
+}
+
+// RegionZoneMappingSpec defines the desired state of RegionZoneMapping
+type RegionZoneMappingSpec struct {
+	// RegionLabel is the node label holding the region of the node
+	// +kubebuilder:default="topology.kubernetes.io/region"
+	// +optional
+	RegionLabel string `json:"regionLabel,omitempty"`
+
+	// UseDefaultMappings includes the built-in mappings of the AWS, GCP and
+	// Azure regions; mappings in the spec take precedence over them
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Type=boolean
+	// +optional
+	UseDefaultMappings *bool `json:"useDefaultMappings,omitempty"`
+
+	// Mappings maps additional or on-prem regions to provider zones
+	// +listType=map
+	// +listMapKey=region
+	// +optional
+	Mappings []RegionMapping `json:"mappings,omitempty"`
+
+	// ForecastRefreshInterval of the issuers created for the regions
+	// +kubebuilder:default="12h"
+	// +kubebuilder:validation:Type=string
+	// +optional
+	ForecastRefreshInterval *metav1.Duration `json:"forecastRefreshInterval,omitempty"`
+
+	// LiveRefreshInterval of the issuers created for the regions
+	// +kubebuilder:default="1h"
+	// +kubebuilder:validation:Type=string
+	// +optional
+	LiveRefreshInterval *metav1.Duration `json:"liveRefreshInterval,omitempty"`
+
+	// EmissionsType of the issuers created for the regions
+	// +kubebuilder:validation:Enum=average;marginal
+	// +optional
+	EmissionsType string `json:"emissionsType,omitempty"`
+
+	// ProviderRef of the issuers created for the regions
+	// +kubebuilder:validation:Required
+	ProviderRef *v1.ObjectReference `json:"providerRef"`
+}
+
+// MappedRegion is a region of the cluster and the issuer created for it
+type MappedRegion struct {
+	Region string `json:"region"`
+	Zone   string `json:"zone"`
+	Issuer string `json:"issuer"`
+}
+
+// RegionZoneMappingStatus defines the observed state of RegionZoneMapping
+type RegionZoneMappingStatus struct {
+	// Regions are the regions of the cluster that are mapped to a zone
+	Regions []MappedRegion `json:"regions,omitempty"`
+	// UnmappedRegions are the regions of the cluster no zone is known for
+	UnmappedRegions []string     `json:"unmappedRegions,omitempty"`
+	LastUpdate      *metav1.Time `json:"lastUpdate,omitempty"`
+
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// RegionZoneMapping is the Schema for the regionzonemappings API
+// +kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.spec.providerRef.name`
+// +kubebuilder:printcolumn:name="Unmapped",type=string,JSONPath=`.status.unmappedRegions`
+// +kubebuilder:printcolumn:name="Last Update",type=string,JSONPath=`.status.lastUpdate`
+type RegionZoneMapping struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RegionZoneMappingSpec   `json:"spec,omitempty"`
+	Status RegionZoneMappingStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RegionZoneMappingList contains a list of RegionZoneMapping
+type RegionZoneMappingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RegionZoneMapping `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RegionZoneMapping{}, &RegionZoneMappingList{})
+}
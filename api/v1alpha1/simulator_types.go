@@ -32,8 +32,7 @@ type SimulatorSpec struct {
 
 // SimulatorStatus defines the observed state of Simulator
 type SimulatorStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	ProviderStatus `json:",inline"`
 }
 
 //+kubebuilder:object:root=true
@@ -41,6 +40,8 @@ type SimulatorStatus struct {
 
 // Simulator is the Schema for the simulators API
 // +kubebuilder:printcolumn:name="Randomize",type=string,JSONPath=`.spec.randomize`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Last Contact",type=string,JSONPath=`.status.lastContact`
 type Simulator struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -0,0 +1,40 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+func (r *Simulator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-core-rekuberate-io-v1alpha1-simulator,mutating=true,failurePolicy=fail,sideEffects=None,groups=core.rekuberate.io,resources=simulators,verbs=create;update,versions=v1alpha1,name=msimulator.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &Simulator{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *Simulator) Default() {
+	if r.Spec.Randomize == nil {
+		randomize := false
+		r.Spec.Randomize = &randomize
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+)
+
+func TestSimulatorDefault(t *testing.T) {
+	enabled := true
+
+	tests := []struct {
+		name      string
+		randomize *bool
+		want      bool
+	}{
+		{name: "unset", randomize: nil, want: false},
+		{name: "kept", randomize: &enabled, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Simulator{Spec: SimulatorSpec{Randomize: tt.randomize}}
+			r.Default()
+
+			if r.Spec.Randomize == nil || *r.Spec.Randomize != tt.want {
+				t.Errorf("randomize = %v, want %t", r.Spec.Randomize, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// StaticTableDirectory is the directory of the controller container that
+	// files of static tables are mounted to
+	StaticTableDirectory = "/var/lib/carbon/tables"
+)
+
+// StaticTableSource is where the table is read from; ConfigMaps and Secrets
+// are taken from the namespace of the StaticTable.
+// +kubebuilder:validation:XValidation:rule="(has(self.configMapKeyRef) ? 1 : 0) + (has(self.secretKeyRef) ? 1 : 0) + (has(self.file) ? 1 : 0) == 1",message="exactly one of configMapKeyRef, secretKeyRef or file must be set"
+type StaticTableSource struct {
+	// +optional
+	ConfigMapKeyRef *v1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// +optional
+	SecretKeyRef *v1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// File is the path of a table mounted into the controller container,
+	// relative to /var/lib/carbon/tables
+	// +optional
+	File string `json:"file,omitempty"`
+}
+
+// StaticTableSpec defines the desired state of StaticTable
+type StaticTableSpec struct {
+	// +kubebuilder:validation:Required
+	Source StaticTableSource `json:"source"`
+
+	// Format of the table; CSV tables have a header row with the columns
+	// timestamp, carbonIntensity and optionally zone, JSON tables are arrays of
+	// objects with the same fields. Timestamps are RFC 3339, rows without a
+	// zone apply to every zone.
+	// +kubebuilder:validation:Enum=csv;json
+	// +kubebuilder:default:=csv
+	Format string `json:"format,omitempty"`
+
+	// Repeat repeats the table every day or week; only the time of day, or the
+	// weekday and time, of the timestamps is used then
+	// +kubebuilder:validation:Enum=none;daily;weekly
+	// +kubebuilder:default:=none
+	Repeat string `json:"repeat,omitempty"`
+
+	// Resolution is how long the value of a row is valid
+	// +kubebuilder:default="1h"
+	// +kubebuilder:validation:Type=string
+	// +optional
+	Resolution *metav1.Duration `json:"resolution,omitempty"`
+
+	// ForecastHorizon is how far into the future the forecast reaches
+	// +kubebuilder:default="24h"
+	// +kubebuilder:validation:Type=string
+	// +optional
+	ForecastHorizon *metav1.Duration `json:"forecastHorizon,omitempty"`
+
+	// +kubebuilder:validation:Enum=average;marginal
+	// +kubebuilder:default:=average
+	EmissionsType string `json:"emissionsType,omitempty"`
+}
+
+// StaticTableStatus defines the observed state of StaticTable
+type StaticTableStatus struct {
+	ProviderStatus `json:",inline"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// StaticTable is the Schema for the statictables API. It delivers carbon
+// intensities from a published table, for clusters without access to any
+// carbon intensity service.
+// +kubebuilder:printcolumn:name="Format",type=string,JSONPath=`.spec.format`
+// +kubebuilder:printcolumn:name="Repeat",type=string,JSONPath=`.spec.repeat`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Last Contact",type=string,JSONPath=`.status.lastContact`
+type StaticTable struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   StaticTableSpec   `json:"spec,omitempty"`
+	Status StaticTableStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// StaticTableList contains a list of StaticTable
+type StaticTableList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StaticTable `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&StaticTable{}, &StaticTableList{})
+}
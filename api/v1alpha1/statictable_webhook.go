@@ -0,0 +1,86 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"path/filepath"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+func (r *StaticTable) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-core-rekuberate-io-v1alpha1-statictable,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.rekuberate.io,resources=statictables,verbs=create;update,versions=v1alpha1,name=vstatictable.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &StaticTable{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *StaticTable) ValidateCreate() error {
+	return r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *StaticTable) ValidateUpdate(old runtime.Object) error {
+	return r.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *StaticTable) ValidateDelete() error {
+	return nil
+}
+
+func (r *StaticTable) validate() error {
+	var allErrs field.ErrorList
+	sourcePath := field.NewPath("spec", "source")
+
+	if file := r.Spec.Source.File; file != "" && !IsStaticTableFile(file) {
+		allErrs = append(allErrs, field.Invalid(sourcePath.Child("file"), file, "must be a path relative to "+StaticTableDirectory))
+	}
+
+	if ref := r.Spec.Source.ConfigMapKeyRef; ref != nil && (ref.Name == "" || ref.Key == "") {
+		allErrs = append(allErrs, field.Required(sourcePath.Child("configMapKeyRef"), "name and key are required"))
+	}
+
+	if ref := r.Spec.Source.SecretKeyRef; ref != nil && (ref.Name == "" || ref.Key == "") {
+		allErrs = append(allErrs, field.Required(sourcePath.Child("secretKeyRef"), "name and key are required"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(GroupVersion.WithKind("StaticTable").GroupKind(), r.Name, allErrs)
+}
+
+// IsStaticTableFile reports whether the path stays within StaticTableDirectory.
+func IsStaticTableFile(path string) bool {
+	if filepath.IsAbs(path) {
+		return false
+	}
+
+	clean := filepath.Clean(path)
+	return clean != "." && clean != ".." && !strings.HasPrefix(clean, "../")
+}
@@ -31,12 +31,17 @@ type WattTimeSpec struct {
 
 	Username string              `json:"username"`
 	Password *v1.SecretReference `json:"password"`
+
+	// APIVersion selects the version of the WattTime API
+	// +kubebuilder:validation:Enum=v2;v3
+	// +kubebuilder:default:=v2
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
 }
 
 // WattTimeStatus defines the observed state of WattTime
 type WattTimeStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	ProviderStatus `json:",inline"`
 }
 
 //+kubebuilder:object:root=true
@@ -44,6 +49,9 @@ type WattTimeStatus struct {
 
 // WattTime is the Schema for the watttimes API
 // +kubebuilder:printcolumn:name="Username",type=string,JSONPath=`.spec.username`
+// +kubebuilder:printcolumn:name="API",type=string,JSONPath=`.spec.apiVersion`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Last Contact",type=string,JSONPath=`.status.lastContact`
 type WattTime struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
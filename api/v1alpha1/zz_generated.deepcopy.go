@@ -24,7 +24,7 @@ package v1alpha1
 import (
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -126,6 +126,1047 @@ func (in *CarbonIntensityIssuerStatus) DeepCopyInto(out *CarbonIntensityIssuerSt
 		*out = new(string)
 		**out = **in
 	}
+	if in.ObservedAt != nil {
+		in, out := &in.ObservedAt, &out.ObservedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.IsEstimated != nil {
+		in, out := &in.IsEstimated, &out.IsEstimated
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CarbonIntensityIssuerStatus.
+func (in *CarbonIntensityIssuerStatus) DeepCopy() *CarbonIntensityIssuerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CarbonIntensityIssuerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositeProvider) DeepCopyInto(out *CompositeProvider) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositeProvider.
+func (in *CompositeProvider) DeepCopy() *CompositeProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositeProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CompositeProvider) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositeProviderList) DeepCopyInto(out *CompositeProviderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CompositeProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositeProviderList.
+func (in *CompositeProviderList) DeepCopy() *CompositeProviderList {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositeProviderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CompositeProviderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositeProviderMember) DeepCopyInto(out *CompositeProviderMember) {
+	*out = *in
+	if in.ProviderRef != nil {
+		in, out := &in.ProviderRef, &out.ProviderRef
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositeProviderMember.
+func (in *CompositeProviderMember) DeepCopy() *CompositeProviderMember {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositeProviderMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositeProviderSpec) DeepCopyInto(out *CompositeProviderSpec) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]CompositeProviderMember, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositeProviderSpec.
+func (in *CompositeProviderSpec) DeepCopy() *CompositeProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositeProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositeProviderStatus) DeepCopyInto(out *CompositeProviderStatus) {
+	*out = *in
+	in.ProviderStatus.DeepCopyInto(&out.ProviderStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositeProviderStatus.
+func (in *CompositeProviderStatus) DeepCopy() *CompositeProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositeProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EIA) DeepCopyInto(out *EIA) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EIA.
+func (in *EIA) DeepCopy() *EIA {
+	if in == nil {
+		return nil
+	}
+	out := new(EIA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EIA) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EIAList) DeepCopyInto(out *EIAList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EIA, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EIAList.
+func (in *EIAList) DeepCopy() *EIAList {
+	if in == nil {
+		return nil
+	}
+	out := new(EIAList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EIAList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EIASpec) DeepCopyInto(out *EIASpec) {
+	*out = *in
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.ApiKeyRef != nil {
+		in, out := &in.ApiKeyRef, &out.ApiKeyRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	if in.EmissionFactorSetRef != nil {
+		in, out := &in.EmissionFactorSetRef, &out.EmissionFactorSetRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.EmissionFactors != nil {
+		in, out := &in.EmissionFactors, &out.EmissionFactors
+		*out = make(map[string]EmissionFactor, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EIASpec.
+func (in *EIASpec) DeepCopy() *EIASpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EIASpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EIAStatus) DeepCopyInto(out *EIAStatus) {
+	*out = *in
+	in.ProviderStatus.DeepCopyInto(&out.ProviderStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EIAStatus.
+func (in *EIAStatus) DeepCopy() *EIAStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EIAStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ENTSOE) DeepCopyInto(out *ENTSOE) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ENTSOE.
+func (in *ENTSOE) DeepCopy() *ENTSOE {
+	if in == nil {
+		return nil
+	}
+	out := new(ENTSOE)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ENTSOE) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ENTSOEList) DeepCopyInto(out *ENTSOEList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ENTSOE, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ENTSOEList.
+func (in *ENTSOEList) DeepCopy() *ENTSOEList {
+	if in == nil {
+		return nil
+	}
+	out := new(ENTSOEList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ENTSOEList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ENTSOESpec) DeepCopyInto(out *ENTSOESpec) {
+	*out = *in
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecurityTokenRef != nil {
+		in, out := &in.SecurityTokenRef, &out.SecurityTokenRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	if in.EmissionFactorSetRef != nil {
+		in, out := &in.EmissionFactorSetRef, &out.EmissionFactorSetRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.EmissionFactors != nil {
+		in, out := &in.EmissionFactors, &out.EmissionFactors
+		*out = make(map[string]EmissionFactor, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ENTSOESpec.
+func (in *ENTSOESpec) DeepCopy() *ENTSOESpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ENTSOESpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ENTSOEStatus) DeepCopyInto(out *ENTSOEStatus) {
+	*out = *in
+	in.ProviderStatus.DeepCopyInto(&out.ProviderStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ENTSOEStatus.
+func (in *ENTSOEStatus) DeepCopy() *ENTSOEStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ENTSOEStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElectricityMaps) DeepCopyInto(out *ElectricityMaps) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElectricityMaps.
+func (in *ElectricityMaps) DeepCopy() *ElectricityMaps {
+	if in == nil {
+		return nil
+	}
+	out := new(ElectricityMaps)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElectricityMaps) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElectricityMapsList) DeepCopyInto(out *ElectricityMapsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElectricityMaps, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElectricityMapsList.
+func (in *ElectricityMapsList) DeepCopy() *ElectricityMapsList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElectricityMapsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElectricityMapsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElectricityMapsSpec) DeepCopyInto(out *ElectricityMapsSpec) {
+	*out = *in
+	if in.CommercialTrialEndpoint != nil {
+		in, out := &in.CommercialTrialEndpoint, &out.CommercialTrialEndpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.ApiKeyRef != nil {
+		in, out := &in.ApiKeyRef, &out.ApiKeyRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElectricityMapsSpec.
+func (in *ElectricityMapsSpec) DeepCopy() *ElectricityMapsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElectricityMapsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElectricityMapsStatus) DeepCopyInto(out *ElectricityMapsStatus) {
+	*out = *in
+	in.ProviderStatus.DeepCopyInto(&out.ProviderStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElectricityMapsStatus.
+func (in *ElectricityMapsStatus) DeepCopy() *ElectricityMapsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElectricityMapsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmissionFactorSet) DeepCopyInto(out *EmissionFactorSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmissionFactorSet.
+func (in *EmissionFactorSet) DeepCopy() *EmissionFactorSet {
+	if in == nil {
+		return nil
+	}
+	out := new(EmissionFactorSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EmissionFactorSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmissionFactorSetList) DeepCopyInto(out *EmissionFactorSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EmissionFactorSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmissionFactorSetList.
+func (in *EmissionFactorSetList) DeepCopy() *EmissionFactorSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(EmissionFactorSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EmissionFactorSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmissionFactorSetSpec) DeepCopyInto(out *EmissionFactorSetSpec) {
+	*out = *in
+	if in.UseDefaults != nil {
+		in, out := &in.UseDefaults, &out.UseDefaults
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Factors != nil {
+		in, out := &in.Factors, &out.Factors
+		*out = make(map[string]EmissionFactor, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]ZoneEmissionFactors, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmissionFactorSetSpec.
+func (in *EmissionFactorSetSpec) DeepCopy() *EmissionFactorSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EmissionFactorSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericHTTP) DeepCopyInto(out *GenericHTTP) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericHTTP.
+func (in *GenericHTTP) DeepCopy() *GenericHTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericHTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GenericHTTP) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericHTTPAuth) DeepCopyInto(out *GenericHTTPAuth) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.QueryParameters != nil {
+		in, out := &in.QueryParameters, &out.QueryParameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericHTTPAuth.
+func (in *GenericHTTPAuth) DeepCopy() *GenericHTTPAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericHTTPAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericHTTPCurrent) DeepCopyInto(out *GenericHTTPCurrent) {
+	*out = *in
+	out.Value = in.Value
+	if in.Timestamp != nil {
+		in, out := &in.Timestamp, &out.Timestamp
+		*out = new(GenericHTTPExpression)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericHTTPCurrent.
+func (in *GenericHTTPCurrent) DeepCopy() *GenericHTTPCurrent {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericHTTPCurrent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericHTTPExpression) DeepCopyInto(out *GenericHTTPExpression) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericHTTPExpression.
+func (in *GenericHTTPExpression) DeepCopy() *GenericHTTPExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericHTTPExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericHTTPForecast) DeepCopyInto(out *GenericHTTPForecast) {
+	*out = *in
+	out.Points = in.Points
+	out.Value = in.Value
+	out.Timestamp = in.Timestamp
+	if in.Horizon != nil {
+		in, out := &in.Horizon, &out.Horizon
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericHTTPForecast.
+func (in *GenericHTTPForecast) DeepCopy() *GenericHTTPForecast {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericHTTPForecast)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericHTTPList) DeepCopyInto(out *GenericHTTPList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GenericHTTP, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericHTTPList.
+func (in *GenericHTTPList) DeepCopy() *GenericHTTPList {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericHTTPList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GenericHTTPList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericHTTPSpec) DeepCopyInto(out *GenericHTTPSpec) {
+	*out = *in
+	in.Current.DeepCopyInto(&out.Current)
+	if in.Forecast != nil {
+		in, out := &in.Forecast, &out.Forecast
+		*out = new(GenericHTTPForecast)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(GenericHTTPAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resolution != nil {
+		in, out := &in.Resolution, &out.Resolution
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericHTTPSpec.
+func (in *GenericHTTPSpec) DeepCopy() *GenericHTTPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericHTTPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericHTTPStatus) DeepCopyInto(out *GenericHTTPStatus) {
+	*out = *in
+	in.ProviderStatus.DeepCopyInto(&out.ProviderStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericHTTPStatus.
+func (in *GenericHTTPStatus) DeepCopy() *GenericHTTPStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericHTTPStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MappedRegion) DeepCopyInto(out *MappedRegion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MappedRegion.
+func (in *MappedRegion) DeepCopy() *MappedRegion {
+	if in == nil {
+		return nil
+	}
+	out := new(MappedRegion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NationalGrid) DeepCopyInto(out *NationalGrid) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NationalGrid.
+func (in *NationalGrid) DeepCopy() *NationalGrid {
+	if in == nil {
+		return nil
+	}
+	out := new(NationalGrid)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NationalGrid) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NationalGridList) DeepCopyInto(out *NationalGridList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NationalGrid, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NationalGridList.
+func (in *NationalGridList) DeepCopy() *NationalGridList {
+	if in == nil {
+		return nil
+	}
+	out := new(NationalGridList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NationalGridList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NationalGridSpec) DeepCopyInto(out *NationalGridSpec) {
+	*out = *in
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NationalGridSpec.
+func (in *NationalGridSpec) DeepCopy() *NationalGridSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NationalGridSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NationalGridStatus) DeepCopyInto(out *NationalGridStatus) {
+	*out = *in
+	in.ProviderStatus.DeepCopyInto(&out.ProviderStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NationalGridStatus.
+func (in *NationalGridStatus) DeepCopy() *NationalGridStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NationalGridStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusQuery) DeepCopyInto(out *PrometheusQuery) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusQuery.
+func (in *PrometheusQuery) DeepCopy() *PrometheusQuery {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusQuery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PrometheusQuery) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusQueryAuth) DeepCopyInto(out *PrometheusQueryAuth) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusQueryAuth.
+func (in *PrometheusQueryAuth) DeepCopy() *PrometheusQueryAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusQueryAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusQueryForecast) DeepCopyInto(out *PrometheusQueryForecast) {
+	*out = *in
+	if in.Horizon != nil {
+		in, out := &in.Horizon, &out.Horizon
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusQueryForecast.
+func (in *PrometheusQueryForecast) DeepCopy() *PrometheusQueryForecast {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusQueryForecast)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusQueryList) DeepCopyInto(out *PrometheusQueryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PrometheusQuery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusQueryList.
+func (in *PrometheusQueryList) DeepCopy() *PrometheusQueryList {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusQueryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PrometheusQueryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusQuerySpec) DeepCopyInto(out *PrometheusQuerySpec) {
+	*out = *in
+	if in.Forecast != nil {
+		in, out := &in.Forecast, &out.Forecast
+		*out = new(PrometheusQueryForecast)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(PrometheusQueryAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resolution != nil {
+		in, out := &in.Resolution, &out.Resolution
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusQuerySpec.
+func (in *PrometheusQuerySpec) DeepCopy() *PrometheusQuerySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusQuerySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusQueryStatus) DeepCopyInto(out *PrometheusQueryStatus) {
+	*out = *in
+	in.ProviderStatus.DeepCopyInto(&out.ProviderStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusQueryStatus.
+func (in *PrometheusQueryStatus) DeepCopy() *PrometheusQueryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusQueryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderStatus) DeepCopyInto(out *ProviderStatus) {
+	*out = *in
+	if in.LastContact != nil {
+		in, out := &in.LastContact, &out.LastContact
+		*out = (*in).DeepCopy()
+	}
+	if in.LastCheck != nil {
+		in, out := &in.LastCheck, &out.LastCheck
+		*out = (*in).DeepCopy()
+	}
+	if in.DependentIssuers != nil {
+		in, out := &in.DependentIssuers, &out.DependentIssuers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -135,37 +1176,52 @@ func (in *CarbonIntensityIssuerStatus) DeepCopyInto(out *CarbonIntensityIssuerSt
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CarbonIntensityIssuerStatus.
-func (in *CarbonIntensityIssuerStatus) DeepCopy() *CarbonIntensityIssuerStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderStatus.
+func (in *ProviderStatus) DeepCopy() *ProviderStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CarbonIntensityIssuerStatus)
+	out := new(ProviderStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElectricityMaps) DeepCopyInto(out *ElectricityMaps) {
+func (in *RegionMapping) DeepCopyInto(out *RegionMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionMapping.
+func (in *RegionMapping) DeepCopy() *RegionMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(RegionMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegionZoneMapping) DeepCopyInto(out *RegionZoneMapping) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElectricityMaps.
-func (in *ElectricityMaps) DeepCopy() *ElectricityMaps {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionZoneMapping.
+func (in *RegionZoneMapping) DeepCopy() *RegionZoneMapping {
 	if in == nil {
 		return nil
 	}
-	out := new(ElectricityMaps)
+	out := new(RegionZoneMapping)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ElectricityMaps) DeepCopyObject() runtime.Object {
+func (in *RegionZoneMapping) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -173,31 +1229,31 @@ func (in *ElectricityMaps) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElectricityMapsList) DeepCopyInto(out *ElectricityMapsList) {
+func (in *RegionZoneMappingList) DeepCopyInto(out *RegionZoneMappingList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ElectricityMaps, len(*in))
+		*out = make([]RegionZoneMapping, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElectricityMapsList.
-func (in *ElectricityMapsList) DeepCopy() *ElectricityMapsList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionZoneMappingList.
+func (in *RegionZoneMappingList) DeepCopy() *RegionZoneMappingList {
 	if in == nil {
 		return nil
 	}
-	out := new(ElectricityMapsList)
+	out := new(RegionZoneMappingList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ElectricityMapsList) DeepCopyObject() runtime.Object {
+func (in *RegionZoneMappingList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -205,41 +1261,77 @@ func (in *ElectricityMapsList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElectricityMapsSpec) DeepCopyInto(out *ElectricityMapsSpec) {
+func (in *RegionZoneMappingSpec) DeepCopyInto(out *RegionZoneMappingSpec) {
 	*out = *in
-	if in.CommercialTrialEndpoint != nil {
-		in, out := &in.CommercialTrialEndpoint, &out.CommercialTrialEndpoint
-		*out = new(string)
+	if in.UseDefaultMappings != nil {
+		in, out := &in.UseDefaultMappings, &out.UseDefaultMappings
+		*out = new(bool)
 		**out = **in
 	}
-	if in.ApiKeyRef != nil {
-		in, out := &in.ApiKeyRef, &out.ApiKeyRef
-		*out = new(v1.SecretReference)
+	if in.Mappings != nil {
+		in, out := &in.Mappings, &out.Mappings
+		*out = make([]RegionMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForecastRefreshInterval != nil {
+		in, out := &in.ForecastRefreshInterval, &out.ForecastRefreshInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.LiveRefreshInterval != nil {
+		in, out := &in.LiveRefreshInterval, &out.LiveRefreshInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ProviderRef != nil {
+		in, out := &in.ProviderRef, &out.ProviderRef
+		*out = new(v1.ObjectReference)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElectricityMapsSpec.
-func (in *ElectricityMapsSpec) DeepCopy() *ElectricityMapsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionZoneMappingSpec.
+func (in *RegionZoneMappingSpec) DeepCopy() *RegionZoneMappingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ElectricityMapsSpec)
+	out := new(RegionZoneMappingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElectricityMapsStatus) DeepCopyInto(out *ElectricityMapsStatus) {
+func (in *RegionZoneMappingStatus) DeepCopyInto(out *RegionZoneMappingStatus) {
 	*out = *in
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]MappedRegion, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnmappedRegions != nil {
+		in, out := &in.UnmappedRegions, &out.UnmappedRegions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastUpdate != nil {
+		in, out := &in.LastUpdate, &out.LastUpdate
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElectricityMapsStatus.
-func (in *ElectricityMapsStatus) DeepCopy() *ElectricityMapsStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionZoneMappingStatus.
+func (in *RegionZoneMappingStatus) DeepCopy() *RegionZoneMappingStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ElectricityMapsStatus)
+	out := new(RegionZoneMappingStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -250,7 +1342,7 @@ func (in *Simulator) DeepCopyInto(out *Simulator) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Simulator.
@@ -326,6 +1418,7 @@ func (in *SimulatorSpec) DeepCopy() *SimulatorSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SimulatorStatus) DeepCopyInto(out *SimulatorStatus) {
 	*out = *in
+	in.ProviderStatus.DeepCopyInto(&out.ProviderStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SimulatorStatus.
@@ -338,13 +1431,139 @@ func (in *SimulatorStatus) DeepCopy() *SimulatorStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticTable) DeepCopyInto(out *StaticTable) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticTable.
+func (in *StaticTable) DeepCopy() *StaticTable {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticTable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StaticTable) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticTableList) DeepCopyInto(out *StaticTableList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]StaticTable, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticTableList.
+func (in *StaticTableList) DeepCopy() *StaticTableList {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticTableList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StaticTableList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticTableSource) DeepCopyInto(out *StaticTableSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticTableSource.
+func (in *StaticTableSource) DeepCopy() *StaticTableSource {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticTableSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticTableSpec) DeepCopyInto(out *StaticTableSpec) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	if in.Resolution != nil {
+		in, out := &in.Resolution, &out.Resolution
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ForecastHorizon != nil {
+		in, out := &in.ForecastHorizon, &out.ForecastHorizon
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticTableSpec.
+func (in *StaticTableSpec) DeepCopy() *StaticTableSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticTableSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticTableStatus) DeepCopyInto(out *StaticTableStatus) {
+	*out = *in
+	in.ProviderStatus.DeepCopyInto(&out.ProviderStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticTableStatus.
+func (in *StaticTableStatus) DeepCopy() *StaticTableStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticTableStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WattTime) DeepCopyInto(out *WattTime) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WattTime.
@@ -420,6 +1639,7 @@ func (in *WattTimeSpec) DeepCopy() *WattTimeSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WattTimeStatus) DeepCopyInto(out *WattTimeStatus) {
 	*out = *in
+	in.ProviderStatus.DeepCopyInto(&out.ProviderStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WattTimeStatus.
@@ -431,3 +1651,25 @@ func (in *WattTimeStatus) DeepCopy() *WattTimeStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneEmissionFactors) DeepCopyInto(out *ZoneEmissionFactors) {
+	*out = *in
+	if in.Factors != nil {
+		in, out := &in.Factors, &out.Factors
+		*out = make(map[string]EmissionFactor, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneEmissionFactors.
+func (in *ZoneEmissionFactors) DeepCopy() *ZoneEmissionFactors {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneEmissionFactors)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,76 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ProviderInitPending  = "ProviderInitPending"
+	ProviderInitFailed   = "ProviderInitFailed"
+	ProviderInitFinished = "ProviderInitFinished"
+
+	EmissionsTypePending     = "EmissionsTypePending"
+	EmissionsTypeSupported   = "EmissionsTypeSupported"
+	EmissionsTypeUnsupported = "EmissionsTypeUnsupported"
+
+	ForecastPending     = "ForecastPending"
+	ForecastSupported   = "ForecastSupported"
+	ForecastUnsupported = "ForecastUnsupported"
+
+	ZonePending          = "ZonePending"
+	ZoneValid            = "ZoneValid"
+	ZoneUnknown          = "ZoneUnknown"
+	ZoneValidationFailed = "ZoneValidationFailed"
+
+	ZoneResolved              = "ZoneResolved"
+	ZoneResolutionFailed      = "ZoneResolutionFailed"
+	ZoneResolutionUnsupported = "ZoneResolutionUnsupported"
+
+	HistoryBackfilled     = "HistoryBackfilled"
+	HistoryBackfillFailed = "HistoryBackfillFailed"
+)
+
+var (
+	ConditionHealthy = metav1.Condition{
+		Type:   "Available",
+		Status: metav1.ConditionUnknown,
+		Reason: ProviderInitPending,
+	}
+
+	ConditionEmissionsTypeSupported = metav1.Condition{
+		Type:   "EmissionsTypeSupported",
+		Status: metav1.ConditionUnknown,
+		Reason: EmissionsTypePending,
+	}
+
+	ConditionForecastAvailable = metav1.Condition{
+		Type:   "ForecastAvailable",
+		Status: metav1.ConditionUnknown,
+		Reason: ForecastPending,
+	}
+
+	ConditionZoneValid = metav1.Condition{
+		Type:   "ZoneValid",
+		Status: metav1.ConditionUnknown,
+		Reason: ZonePending,
+	}
+
+	// ConditionHistoryBackfilled is only set once the history of an issuer
+	// was backfilled from its provider, or the backfill failed
+	ConditionHistoryBackfilled = metav1.Condition{
+		Type:   "HistoryBackfilled",
+		Status: metav1.ConditionUnknown,
+		Reason: HistoryBackfilled,
+	}
+)
+
+func GetConditions() []metav1.Condition {
+	conditions := []metav1.Condition{
+		ConditionHealthy,
+		ConditionEmissionsTypeSupported,
+		ConditionForecastAvailable,
+		ConditionZoneValid,
+	}
+
+	return conditions
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Location is a geographic position in decimal degrees (WGS 84)
+type Location struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^-?[0-9]+(\.[0-9]+)?$`
+	// +kubebuilder:validation:XValidation:rule="double(self) >= -90.0 && double(self) <= 90.0",message="latitude must be between -90 and 90"
+	Latitude string `json:"latitude"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^-?[0-9]+(\.[0-9]+)?$`
+	// +kubebuilder:validation:XValidation:rule="double(self) >= -180.0 && double(self) <= 180.0",message="longitude must be between -180 and 180"
+	Longitude string `json:"longitude"`
+
+	// RefreshInterval is the interval the zone of the location is resolved in
+	// +kubebuilder:default="24h"
+	// +kubebuilder:validation:Type=string
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// Coordinates returns the latitude and longitude of the location.
+func (l *Location) Coordinates() (latitude float64, longitude float64, err error) {
+	latitude, err = strconv.ParseFloat(l.Latitude, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	longitude, err = strconv.ParseFloat(l.Longitude, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return latitude, longitude, nil
+}
+
+// HistorySpec configures the history of carbon intensities an issuer keeps
+// +kubebuilder:validation:XValidation:rule="duration(self.backfill) <= duration(self.retention)",message="backfill must not exceed retention"
+type HistorySpec struct {
+	// Backfill is the look-back window loaded from the provider when the
+	// issuer is created, if the provider serves past carbon intensities
+	// +kubebuilder:default="24h"
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('0s') && duration(self) <= duration('720h')",message="backfill must be between 0s and 720h"
+	Backfill metav1.Duration `json:"backfill"`
+
+	// Retention is the window of carbon intensities kept in the history, 0s
+	// disables the history
+	// +kubebuilder:default="168h"
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('0s') && duration(self) <= duration('720h')",message="retention must be between 0s and 720h"
+	Retention metav1.Duration `json:"retention"`
+}
+
+// CarbonIntensityIssuerSpec defines the desired state of CarbonIntensityIssuer
+// +kubebuilder:validation:XValidation:rule="has(self.zone) != has(self.location)",message="exactly one of zone or location must be set"
+type CarbonIntensityIssuerSpec struct {
+	// ForecastRefreshInterval is the interval the forecast of the zone is refreshed in
+	// +kubebuilder:default="12h"
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('15m') && duration(self) <= duration('48h')",message="forecastRefreshInterval must be between 15m and 48h"
+	ForecastRefreshInterval metav1.Duration `json:"forecastRefreshInterval"`
+
+	// LiveRefreshInterval is the interval the carbon intensity of the zone is refreshed in
+	// +kubebuilder:default="1h"
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('5m') && duration(self) <= duration('24h')",message="liveRefreshInterval must be between 5m and 24h"
+	LiveRefreshInterval metav1.Duration `json:"liveRefreshInterval"`
+
+	// Zone is the grid zone of the issuer, in the naming scheme of the provider
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// Location is resolved to the grid zone of the issuer by the provider,
+	// instead of setting the zone explicitly
+	// +optional
+	Location *Location `json:"location,omitempty"`
+
+	// EmissionsType is the kind of carbon intensity the issuer reports. It
+	// defaults to the first emissions type the provider supports.
+	// +kubebuilder:validation:Enum=average;marginal
+	// +optional
+	EmissionsType string `json:"emissionsType,omitempty"`
+
+	// History configures the history of carbon intensities the issuer keeps
+	// +optional
+	History *HistorySpec `json:"history,omitempty"`
+
+	// +kubebuilder:validation:Required
+	ProviderRef *v1.ObjectReference `json:"providerRef,omitempty"`
+}
+
+// PowerSource is the production of a source of electricity
+type PowerSource struct {
+	Source string `json:"source"`
+	// Production is the power produced by the source, in MW
+	Production string `json:"production"`
+	// Percentage is the share of the source in the total production
+	Percentage string `json:"percentage,omitempty"`
+}
+
+// CarbonIntensityIssuerStatus defines the observed state of CarbonIntensityIssuer
+type CarbonIntensityIssuerStatus struct {
+	// Zone is the grid zone the issuer reports for, either as set in the spec
+	// or as resolved from its location
+	Zone string `json:"zone,omitempty"`
+	// ZoneResolvedAt is the time the zone was last resolved from the location
+	ZoneResolvedAt *metav1.Time `json:"zoneResolvedAt,omitempty"`
+
+	LastForecast    *metav1.Time `json:"lastForecast,omitempty"`
+	LastUpdate      *metav1.Time `json:"lastUpdate,omitempty"`
+	NextUpdate      *metav1.Time `json:"nextUpdate,omitempty"`
+	CarbonIntensity *string      `json:"carbonIntensity,omitempty"`
+
+	// ObservedAt is the time the provider reported the carbon intensity for
+	ObservedAt *metav1.Time `json:"observedAt,omitempty"`
+	// IsEstimated is true when the provider reported an estimated rather than a
+	// measured carbon intensity
+	IsEstimated *bool `json:"isEstimated,omitempty"`
+	// EstimationMethod is the method the provider used to estimate the carbon
+	// intensity, if any
+	EstimationMethod string `json:"estimationMethod,omitempty"`
+	// EmissionsType is the kind of carbon intensity the issuer reports
+	EmissionsType string `json:"emissionsType,omitempty"`
+	// EmissionFactorType is the kind of emission factors (lifecycle or
+	// direct) the provider based the carbon intensity on, if reported
+	EmissionFactorType string `json:"emissionFactorType,omitempty"`
+
+	// RenewablePercentage is the share of renewable sources in the
+	// electricity of the zone, if reported by the provider
+	RenewablePercentage *string `json:"renewablePercentage,omitempty"`
+	// FossilFreePercentage is the share of fossil-free sources in the
+	// electricity of the zone, if reported by the provider
+	FossilFreePercentage *string `json:"fossilFreePercentage,omitempty"`
+	// ProductionMix is the power produced per source in the zone, if
+	// reported by the provider
+	ProductionMix []PowerSource `json:"productionMix,omitempty"`
+	// ServedBy are the members of a CompositeProvider that delivered the
+	// carbon intensity, as kind/namespace/name
+	ServedBy []string `json:"servedBy,omitempty"`
+	// HistoryBackfilledAt is the time the history was backfilled from the
+	// provider
+	HistoryBackfilledAt *metav1.Time `json:"historyBackfilledAt,omitempty"`
+
+	// PowerBreakdownObservedAt is the time the provider reported the power
+	// breakdown for
+	PowerBreakdownObservedAt *metav1.Time `json:"powerBreakdownObservedAt,omitempty"`
+
+	// Conditions store the status conditions of the carbon intensity issuer
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// CarbonIntensityIssuer is the Schema for the carbonintensityissuers API
+// +kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.spec.providerRef.name`
+// +kubebuilder:printcolumn:name="Zone",type=string,JSONPath=`.status.zone`
+// +kubebuilder:printcolumn:name="Forecast INVL",type=string,JSONPath=`.spec.forecastRefreshInterval`
+// +kubebuilder:printcolumn:name="Last Forecast",type=string,JSONPath=`.status.lastForecast`
+// +kubebuilder:printcolumn:name="CI (gCO2eq/KWh)",type=string,JSONPath=`.status.carbonIntensity`
+// +kubebuilder:printcolumn:name="Emissions",type=string,JSONPath=`.status.emissionsType`
+// +kubebuilder:printcolumn:name="Renewable %",type=string,JSONPath=`.status.renewablePercentage`,priority=1
+// +kubebuilder:printcolumn:name="Fossil-Free %",type=string,JSONPath=`.status.fossilFreePercentage`,priority=1
+// +kubebuilder:printcolumn:name="Observed At",type=string,JSONPath=`.status.observedAt`,priority=1
+// +kubebuilder:printcolumn:name="Estimated",type=boolean,JSONPath=`.status.isEstimated`,priority=1
+// +kubebuilder:printcolumn:name="Served By",type=string,JSONPath=`.status.servedBy`,priority=1
+// +kubebuilder:printcolumn:name="Last Update",type=string,JSONPath=`.status.lastUpdate`
+// +kubebuilder:printcolumn:name="Next Update",type=string,JSONPath=`.status.nextUpdate`
+type CarbonIntensityIssuer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CarbonIntensityIssuerSpec   `json:"spec,omitempty"`
+	Status CarbonIntensityIssuerStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CarbonIntensityIssuerList contains a list of CarbonIntensityIssuer
+type CarbonIntensityIssuerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CarbonIntensityIssuer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CarbonIntensityIssuer{}, &CarbonIntensityIssuerList{})
+}
@@ -0,0 +1,251 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CarbonIntensityIssuer) DeepCopyInto(out *CarbonIntensityIssuer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CarbonIntensityIssuer.
+func (in *CarbonIntensityIssuer) DeepCopy() *CarbonIntensityIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(CarbonIntensityIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CarbonIntensityIssuer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CarbonIntensityIssuerList) DeepCopyInto(out *CarbonIntensityIssuerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CarbonIntensityIssuer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CarbonIntensityIssuerList.
+func (in *CarbonIntensityIssuerList) DeepCopy() *CarbonIntensityIssuerList {
+	if in == nil {
+		return nil
+	}
+	out := new(CarbonIntensityIssuerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CarbonIntensityIssuerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CarbonIntensityIssuerSpec) DeepCopyInto(out *CarbonIntensityIssuerSpec) {
+	*out = *in
+	out.ForecastRefreshInterval = in.ForecastRefreshInterval
+	out.LiveRefreshInterval = in.LiveRefreshInterval
+	if in.Location != nil {
+		in, out := &in.Location, &out.Location
+		*out = new(Location)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = new(HistorySpec)
+		**out = **in
+	}
+	if in.ProviderRef != nil {
+		in, out := &in.ProviderRef, &out.ProviderRef
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CarbonIntensityIssuerSpec.
+func (in *CarbonIntensityIssuerSpec) DeepCopy() *CarbonIntensityIssuerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CarbonIntensityIssuerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CarbonIntensityIssuerStatus) DeepCopyInto(out *CarbonIntensityIssuerStatus) {
+	*out = *in
+	if in.ZoneResolvedAt != nil {
+		in, out := &in.ZoneResolvedAt, &out.ZoneResolvedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastForecast != nil {
+		in, out := &in.LastForecast, &out.LastForecast
+		*out = (*in).DeepCopy()
+	}
+	if in.LastUpdate != nil {
+		in, out := &in.LastUpdate, &out.LastUpdate
+		*out = (*in).DeepCopy()
+	}
+	if in.NextUpdate != nil {
+		in, out := &in.NextUpdate, &out.NextUpdate
+		*out = (*in).DeepCopy()
+	}
+	if in.CarbonIntensity != nil {
+		in, out := &in.CarbonIntensity, &out.CarbonIntensity
+		*out = new(string)
+		**out = **in
+	}
+	if in.ObservedAt != nil {
+		in, out := &in.ObservedAt, &out.ObservedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.IsEstimated != nil {
+		in, out := &in.IsEstimated, &out.IsEstimated
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RenewablePercentage != nil {
+		in, out := &in.RenewablePercentage, &out.RenewablePercentage
+		*out = new(string)
+		**out = **in
+	}
+	if in.FossilFreePercentage != nil {
+		in, out := &in.FossilFreePercentage, &out.FossilFreePercentage
+		*out = new(string)
+		**out = **in
+	}
+	if in.ProductionMix != nil {
+		in, out := &in.ProductionMix, &out.ProductionMix
+		*out = make([]PowerSource, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServedBy != nil {
+		in, out := &in.ServedBy, &out.ServedBy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HistoryBackfilledAt != nil {
+		in, out := &in.HistoryBackfilledAt, &out.HistoryBackfilledAt
+		*out = (*in).DeepCopy()
+	}
+	if in.PowerBreakdownObservedAt != nil {
+		in, out := &in.PowerBreakdownObservedAt, &out.PowerBreakdownObservedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CarbonIntensityIssuerStatus.
+func (in *CarbonIntensityIssuerStatus) DeepCopy() *CarbonIntensityIssuerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CarbonIntensityIssuerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HistorySpec) DeepCopyInto(out *HistorySpec) {
+	*out = *in
+	out.Backfill = in.Backfill
+	out.Retention = in.Retention
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HistorySpec.
+func (in *HistorySpec) DeepCopy() *HistorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HistorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Location) DeepCopyInto(out *Location) {
+	*out = *in
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Location.
+func (in *Location) DeepCopy() *Location {
+	if in == nil {
+		return nil
+	}
+	out := new(Location)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerSource) DeepCopyInto(out *PowerSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerSource.
+func (in *PowerSource) DeepCopy() *PowerSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerSource)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -17,13 +17,14 @@ limitations under the License.
 package controllers
 
 import (
-	"bytes"
 	"context"
-	"encoding/gob"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rekuberate-io/carbon/controllers/metrics"
+	"github.com/rekuberate-io/carbon/pkg/common"
 	"github.com/rekuberate-io/carbon/pkg/providers"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -31,8 +32,15 @@ import (
 	"k8s.io/client-go/tools/record"
 	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sort"
+	"strings"
 	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -42,14 +50,49 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	carbonv1beta1 "github.com/rekuberate-io/carbon/api/v1beta1"
 )
 
 const (
 	labelProviderInstance = "core.rekuberate.io/carbon-issuer-instance"
 	labelProviderType     = "core.rekuberate.io/carbon-issuer-type"
 	labelProviderZone     = "core.rekuberate.io/carbon-issuer-zone"
+	labelEmissionsType    = "core.rekuberate.io/carbon-issuer-emissions-type"
+
+	eventReasonRefreshIntervalTooShort = "RefreshIntervalTooShort"
+
+	forecastConfigMapKey     = "forecast.json"
+	forecastConfigMapVersion = "v1"
+
+	defaultZoneRefreshInterval = 24 * time.Hour
 )
 
+var (
+	errZoneResolutionUnsupported = errors.New("provider does not resolve zones from locations")
+)
+
+// forecastPayload is the document published under forecastConfigMapKey in the
+// forecast ConfigMap of every issuer. Consumers should check Version before
+// decoding the rest of the payload.
+type forecastPayload struct {
+	Version       string          `json:"version"`
+	Provider      string          `json:"provider"`
+	Zone          string          `json:"zone"`
+	PublishedAt   time.Time       `json:"publishedAt"`
+	GeneratedAt   time.Time       `json:"generatedAt"`
+	Horizon       string          `json:"horizon"`
+	Resolution    string          `json:"resolution"`
+	Unit          string          `json:"unit"`
+	SignalType    string          `json:"signalType"`
+	EmissionsType string          `json:"emissionsType"`
+	Forecast      []forecastPoint `json:"forecast"`
+}
+
+type forecastPoint struct {
+	PointTime       time.Time `json:"pointTime"`
+	CarbonIntensity float64   `json:"carbonIntensity"`
+}
+
 var (
 	eventFilters = builder.WithPredicates(predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
@@ -82,9 +125,17 @@ type CarbonIntensityIssuerReconciler struct {
 //+kubebuilder:rbac:groups=core.rekuberate.io,resources=electricitymaps,verbs=get;list;watch
 //+kubebuilder:rbac:groups=core.rekuberate.io,resources=watttimes,verbs=get;list;watch
 //+kubebuilder:rbac:groups=core.rekuberate.io,resources=simulators,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core.rekuberate.io,resources=nationalgrids,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core.rekuberate.io,resources=generichttps,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core.rekuberate.io,resources=statictables,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core.rekuberate.io,resources=prometheusqueries,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core.rekuberate.io,resources=compositeproviders,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core.rekuberate.io,resources=entsoes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core.rekuberate.io,resources=eias,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core.rekuberate.io,resources=emissionfactorsets,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
-//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;
-//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -94,9 +145,10 @@ func (r *CarbonIntensityIssuerReconciler) Reconcile(ctx context.Context, req ctr
 	logger = log.FromContext(ctx).WithName("carbon-controller")
 
 	// get carbon intensity provider resource
-	before := &carbonv1alpha1.CarbonIntensityIssuer{}
+	before := &carbonv1beta1.CarbonIntensityIssuer{}
 	if err := r.Get(ctx, req.NamespacedName, before); err != nil {
 		if apierrors.IsNotFound(err) {
+			deleteIssuerMetrics(req.String())
 			return ctrl.Result{}, nil
 		}
 
@@ -108,7 +160,7 @@ func (r *CarbonIntensityIssuerReconciler) Reconcile(ctx context.Context, req ctr
 
 	// initialize status conditions
 	if before.Status.Conditions == nil {
-		conditions := carbonv1alpha1.GetConditions()
+		conditions := carbonv1beta1.GetConditions()
 		for _, condition := range conditions {
 			meta.SetStatusCondition(&after.Status.Conditions, condition)
 		}
@@ -126,10 +178,14 @@ func (r *CarbonIntensityIssuerReconciler) Reconcile(ctx context.Context, req ctr
 	}
 
 	provider, err := providers.GetProvider(ctx, req, r.Client, providerRef)
+	var capabilities *common.Capabilities
+	if err == nil {
+		capabilities, err = provider.GetCapabilities(ctx)
+	}
 	if err != nil {
-		condition := carbonv1alpha1.ConditionHealthy.DeepCopy()
+		condition := carbonv1beta1.ConditionHealthy.DeepCopy()
 		condition.Status = metav1.ConditionFalse
-		condition.Reason = carbonv1alpha1.ProviderInitFailed
+		condition.Reason = carbonv1beta1.ProviderInitFailed
 		condition.Message = err.Error()
 		meta.SetStatusCondition(&after.Status.Conditions, *condition)
 
@@ -139,34 +195,222 @@ func (r *CarbonIntensityIssuerReconciler) Reconcile(ctx context.Context, req ctr
 		return ctrl.Result{}, err
 	}
 
-	condition := carbonv1alpha1.ConditionHealthy.DeepCopy()
+	condition := carbonv1beta1.ConditionHealthy.DeepCopy()
 	condition.Status = metav1.ConditionTrue
-	condition.Reason = carbonv1alpha1.ProviderInitFinished
+	condition.Reason = carbonv1beta1.ProviderInitFinished
 	condition.Message = fmt.Sprintf("Initialized Provider '%s', (%s)", providerRef.Name, providerRef.Kind)
 	meta.SetStatusCondition(&after.Status.Conditions, *condition)
 
+	// validate the issuer against the capabilities of the provider
+	emissionsType, err := resolveEmissionsType(before.Spec.EmissionsType, capabilities)
+	if err != nil {
+		condition := carbonv1beta1.ConditionEmissionsTypeSupported.DeepCopy()
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = carbonv1beta1.EmissionsTypeUnsupported
+		condition.Message = err.Error()
+		meta.SetStatusCondition(&after.Status.Conditions, *condition)
+
+		logger.Error(err, "unsupported emissions type", "providerKind", providerRef.Kind, "emissionsType", before.Spec.EmissionsType)
+		r.Recorder.Event(before, corev1.EventTypeWarning, carbonv1beta1.EmissionsTypeUnsupported, err.Error())
+
+		// retrying is pointless until either the issuer or the provider changes
+		return r.updateStatus(ctx, before, after)
+	}
+
+	condition = carbonv1beta1.ConditionEmissionsTypeSupported.DeepCopy()
+	condition.Status = metav1.ConditionTrue
+	condition.Reason = carbonv1beta1.EmissionsTypeSupported
+	condition.Message = fmt.Sprintf("Provider '%s' (%s) reports %s emissions", providerRef.Name, providerRef.Kind, emissionsType)
+	meta.SetStatusCondition(&after.Status.Conditions, *condition)
+	after.Status.EmissionsType = string(emissionsType)
+
+	condition = carbonv1beta1.ConditionForecastAvailable.DeepCopy()
+	if capabilities.Forecast {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = carbonv1beta1.ForecastSupported
+		condition.Message = fmt.Sprintf(
+			"Provider '%s' (%s) delivers forecasts of %s in %s resolution",
+			providerRef.Name,
+			providerRef.Kind,
+			capabilities.ForecastHorizon,
+			capabilities.Resolution,
+		)
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = carbonv1beta1.ForecastUnsupported
+		condition.Message = fmt.Sprintf("Provider '%s' (%s) does not deliver forecasts", providerRef.Name, providerRef.Kind)
+	}
+	meta.SetStatusCondition(&after.Status.Conditions, *condition)
+
+	// resolve the zone of the issuer from its location, or validate the zone
+	// once per generation of the issuer, listing the zones of a provider is
+	// not free
+	zone := before.Spec.Zone
+	zoneCondition := meta.FindStatusCondition(before.Status.Conditions, carbonv1beta1.ConditionZoneValid.Type)
+	if before.Spec.Location != nil {
+		resolvedZone, resolved, err := resolveZone(ctx, before, zoneCondition, provider)
+		if err != nil {
+			condition = carbonv1beta1.ConditionZoneValid.DeepCopy()
+			condition.ObservedGeneration = before.Generation
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = carbonv1beta1.ZoneResolutionFailed
+			if errors.Is(err, errZoneResolutionUnsupported) {
+				condition.Reason = carbonv1beta1.ZoneResolutionUnsupported
+			}
+			condition.Message = fmt.Sprintf(
+				"Unable to resolve the zone of location %s,%s with provider '%s' (%s): %s",
+				before.Spec.Location.Latitude,
+				before.Spec.Location.Longitude,
+				providerRef.Name,
+				providerRef.Kind,
+				err,
+			)
+			meta.SetStatusCondition(&after.Status.Conditions, *condition)
+
+			logger.Error(err, "unable to resolve zone", "providerKind", providerRef.Kind, "provider", providerRef.Name)
+			r.Recorder.Event(before, corev1.EventTypeWarning, condition.Reason, condition.Message)
+
+			if condition.Reason == carbonv1beta1.ZoneResolutionUnsupported {
+				// retrying is pointless until either the issuer or the provider changes
+				return r.updateStatus(ctx, before, after)
+			}
+
+			r.updateStatus(ctx, before, after)
+			return ctrl.Result{}, err
+		}
+
+		zone = resolvedZone
+		if resolved {
+			after.Status.ZoneResolvedAt = &metav1.Time{Time: time.Now()}
+
+			condition = carbonv1beta1.ConditionZoneValid.DeepCopy()
+			condition.ObservedGeneration = before.Generation
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = carbonv1beta1.ZoneResolved
+			condition.Message = fmt.Sprintf(
+				"Location %s,%s resolved to zone '%s' by provider '%s' (%s)",
+				before.Spec.Location.Latitude,
+				before.Spec.Location.Longitude,
+				zone,
+				providerRef.Name,
+				providerRef.Kind,
+			)
+			meta.SetStatusCondition(&after.Status.Conditions, *condition)
+		}
+	} else if zoneCondition == nil || zoneCondition.Status != metav1.ConditionTrue || zoneCondition.ObservedGeneration != before.Generation {
+		condition = carbonv1beta1.ConditionZoneValid.DeepCopy()
+		condition.ObservedGeneration = before.Generation
+
+		err := providers.ValidateZone(ctx, provider, zone)
+		switch {
+		case err == nil:
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = carbonv1beta1.ZoneValid
+			condition.Message = fmt.Sprintf("Zone '%s' is served by provider '%s' (%s)", zone, providerRef.Name, providerRef.Kind)
+		case errors.Is(err, providers.ErrUnknownZone):
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = carbonv1beta1.ZoneUnknown
+			condition.Message = fmt.Sprintf("Zone '%s' is not served by provider '%s' (%s)", zone, providerRef.Name, providerRef.Kind)
+			meta.SetStatusCondition(&after.Status.Conditions, *condition)
+
+			logger.Error(err, "unknown zone", "providerKind", providerRef.Kind, "provider", providerRef.Name, "zone", zone)
+			r.Recorder.Event(before, corev1.EventTypeWarning, carbonv1beta1.ZoneUnknown, condition.Message)
+
+			// retrying is pointless until either the issuer or the provider changes
+			return r.updateStatus(ctx, before, after)
+		default:
+			// the zone might still be valid, so carry on and let the provider decide
+			condition.Status = metav1.ConditionUnknown
+			condition.Reason = carbonv1beta1.ZoneValidationFailed
+			condition.Message = err.Error()
+
+			logger.Error(err, "unable to validate zone", "providerKind", providerRef.Kind, "provider", providerRef.Name, "zone", zone)
+		}
+
+		meta.SetStatusCondition(&after.Status.Conditions, *condition)
+	}
+
+	if after.Status.Zone != zone {
+		after.Status.Zone = zone
+		after.Status.LastForecast = nil
+		after.Status.HistoryBackfilledAt = nil
+		meta.RemoveStatusCondition(&after.Status.Conditions, carbonv1beta1.ConditionHistoryBackfilled.Type)
+	}
+	if before.Spec.Location == nil {
+		after.Status.ZoneResolvedAt = nil
+	}
+
+	if capabilities.Resolution > 0 && before.Spec.LiveRefreshInterval.Duration < capabilities.Resolution {
+		msg := fmt.Sprintf(
+			"liveRefreshInterval %s is shorter than the resolution %s of provider '%s' (%s)",
+			before.Spec.LiveRefreshInterval.Duration,
+			capabilities.Resolution,
+			providerRef.Name,
+			providerRef.Kind,
+		)
+		logger.Info(msg)
+		r.Recorder.Event(before, corev1.EventTypeWarning, eventReasonRefreshIntervalTooShort, msg)
+	}
+
 	// get current carbon intensity
-	carbonIntensity, err := provider.GetCurrent(ctx, before.Spec.Zone)
+	reading, err := provider.GetCurrent(ctx, zone)
 	if err != nil {
 		logger.Error(err, "unable to get carbon intensity", "providerKind", providerRef.Kind, "provider", providerRef.Name)
 		return ctrl.Result{}, err
 	}
 
+	// get the power breakdown, if the provider reports one; it is only
+	// informational, so the carbon intensity is still issued without it
+	var breakdown *common.PowerBreakdown
+	if breakdownProvider, ok := provider.(providers.PowerBreakdownProvider); ok {
+		breakdown, err = breakdownProvider.GetPowerBreakdown(ctx, zone)
+		if err != nil {
+			logger.Error(err, "unable to get power breakdown", "providerKind", providerRef.Kind, "provider", providerRef.Name)
+			breakdown = nil
+		}
+	}
+
+	// keep the history of the zone, backfilled from the provider if it serves
+	// past carbon intensities
+	providerType := providers.ProviderType(strings.ToLower(providerRef.Kind))
+	if err := r.updateHistory(ctx, before, &after.Status, provider, providerType, zone, reading); err != nil {
+		logger.Error(err, "unable to update carbon intensity history", "configMap", getHistoryConfigMapName(req.Name))
+		return ctrl.Result{}, err
+	}
+
 	// get carbon intensity forecast
-	// TODO: change to time.Hours
-	if before.Status.LastForecast == nil ||
-		before.Status.LastForecast.Add(time.Duration(before.Spec.ForecastRefreshIntervalInHours)*time.Minute).Before(time.Now()) {
-		_, err = provider.GetForecast(ctx, before.Spec.Zone)
+	forecastConfigMap := &corev1.ConfigMap{}
+	forecastConfigMapMissing := false
+	forecastConfigMapObjectKey := client.ObjectKey{Namespace: req.Namespace, Name: getForecastConfigMapName(req.Name)}
+	if err := r.Get(ctx, forecastConfigMapObjectKey, forecastConfigMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "unable to fetch forecast configmap", "configMap", forecastConfigMapObjectKey)
+			return ctrl.Result{}, err
+		}
+
+		forecastConfigMapMissing = true
+	}
+
+	if capabilities.Forecast && (forecastConfigMapMissing || after.Status.LastForecast == nil ||
+		after.Status.LastForecast.Add(before.Spec.ForecastRefreshInterval.Duration).Before(time.Now())) {
+		forecast, err := provider.GetForecast(ctx, zone)
 		if err != nil {
 			logger.Error(err, "unable to get carbon intensity forecast", "providerKind", providerRef.Kind, "provider", providerRef.Name)
 			return ctrl.Result{}, err
 		}
 
-		after.Status.LastForecast = &metav1.Time{Time: time.Now()}
+		lastForecast := time.Now()
+		if err := r.publishForecast(ctx, before, forecast, zone, providerType, lastForecast); err != nil {
+			logger.Error(err, "unable to publish carbon intensity forecast", "configMap", forecastConfigMapObjectKey)
+			return ctrl.Result{}, err
+		}
+
+		after.Status.LastForecast = &metav1.Time{Time: lastForecast}
 	}
 
 	// update rest of the status, push metrics
 
+	carbonIntensity := reading.Value
 	if carbonIntensity > 0 {
 		carbonIntensityAsString := fmt.Sprintf("%.2f", carbonIntensity)
 		after.Status.CarbonIntensity = &carbonIntensityAsString
@@ -175,8 +419,19 @@ func (r *CarbonIntensityIssuerReconciler) Reconcile(ctx context.Context, req ctr
 		after.Status.CarbonIntensity = &notAvailable
 	}
 
-	// TODO: change to time.Hours
-	requeueAfter := time.Minute * time.Duration(before.Spec.LiveRefreshIntervalInHours)
+	after.Status.ObservedAt = nil
+	if !reading.PointTime.IsZero() {
+		after.Status.ObservedAt = &metav1.Time{Time: reading.PointTime}
+	}
+
+	isEstimated := reading.IsEstimated
+	after.Status.IsEstimated = &isEstimated
+	after.Status.EstimationMethod = reading.EstimationMethod
+	after.Status.EmissionFactorType = string(reading.EmissionFactorType)
+	after.Status.ServedBy = reading.ServedBy
+	setPowerBreakdownStatus(&after.Status, breakdown)
+
+	requeueAfter := before.Spec.LiveRefreshInterval.Duration
 	now := time.Now()
 
 	after.Status.NextUpdate = &metav1.Time{Time: now.Add(requeueAfter)}
@@ -187,30 +442,254 @@ func (r *CarbonIntensityIssuerReconciler) Reconcile(ctx context.Context, req ctr
 		return result, err
 	}
 
+	// drop the series of a former provider, zone or emissions type of the
+	// issuer before publishing the current ones
+	deleteIssuerMetrics(req.String())
+
 	// TODO: set N/A value as well in metric
 	if carbonIntensity > 0 {
 		metrics.CipLiveCarbonIntensityMetric.WithLabelValues(
 			providerRef.Kind,
 			req.String(),
-			before.Spec.Zone,
+			zone,
+			string(emissionsType),
 		).Set(carbonIntensity)
 	}
 
+	publishPowerBreakdownMetrics(providerRef.Kind, req.String(), zone, breakdown)
+
 	result.RequeueAfter = requeueAfter
 	return result, nil
 }
 
-// SetupWithManager sets up the controller with the Manager.
+// SetupWithManager sets up the controller with the Manager. Issuers are
+// reconciled as well when the provider object they reference, or its Secret or
+// ConfigMap, changes.
 func (r *CarbonIntensityIssuerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&carbonv1alpha1.CarbonIntensityIssuer{}, eventFilters).
+		For(&carbonv1beta1.CarbonIntensityIssuer{}, eventFilters).
+		Watches(
+			&source.Kind{Type: &carbonv1alpha1.WattTime{}},
+			handler.EnqueueRequestsFromMapFunc(r.findIssuersForProvider),
+			eventFilters,
+		).
+		Watches(
+			&source.Kind{Type: &carbonv1alpha1.ElectricityMaps{}},
+			handler.EnqueueRequestsFromMapFunc(r.findIssuersForProvider),
+			eventFilters,
+		).
+		Watches(
+			&source.Kind{Type: &carbonv1alpha1.Simulator{}},
+			handler.EnqueueRequestsFromMapFunc(r.findIssuersForProvider),
+			eventFilters,
+		).
+		Watches(
+			&source.Kind{Type: &carbonv1alpha1.NationalGrid{}},
+			handler.EnqueueRequestsFromMapFunc(r.findIssuersForProvider),
+			eventFilters,
+		).
+		Watches(
+			&source.Kind{Type: &carbonv1alpha1.GenericHTTP{}},
+			handler.EnqueueRequestsFromMapFunc(r.findIssuersForProvider),
+			eventFilters,
+		).
+		Watches(
+			&source.Kind{Type: &carbonv1alpha1.StaticTable{}},
+			handler.EnqueueRequestsFromMapFunc(r.findIssuersForProvider),
+			eventFilters,
+		).
+		Watches(
+			&source.Kind{Type: &carbonv1alpha1.PrometheusQuery{}},
+			handler.EnqueueRequestsFromMapFunc(r.findIssuersForProvider),
+			eventFilters,
+		).
+		Watches(
+			&source.Kind{Type: &carbonv1alpha1.CompositeProvider{}},
+			handler.EnqueueRequestsFromMapFunc(r.findIssuersForProvider),
+			eventFilters,
+		).
+		Watches(
+			&source.Kind{Type: &carbonv1alpha1.ENTSOE{}},
+			handler.EnqueueRequestsFromMapFunc(r.findIssuersForProvider),
+			eventFilters,
+		).
+		Watches(
+			&source.Kind{Type: &carbonv1alpha1.EIA{}},
+			handler.EnqueueRequestsFromMapFunc(r.findIssuersForProvider),
+			eventFilters,
+		).
+		Watches(
+			&source.Kind{Type: &carbonv1alpha1.EmissionFactorSet{}},
+			handler.EnqueueRequestsFromMapFunc(r.findIssuersForEmissionFactorSet),
+			eventFilters,
+		).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(r.findIssuersForSecret),
+			builder.OnlyMetadata,
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&source.Kind{Type: &corev1.ConfigMap{}},
+			handler.EnqueueRequestsFromMapFunc(r.findIssuersForConfigMap),
+			builder.OnlyMetadata,
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
 		Complete(r)
 }
 
+// findIssuersForProvider enqueues the issuers that reference a provider object.
+func (r *CarbonIntensityIssuerReconciler) findIssuersForProvider(o client.Object) []reconcile.Request {
+	return r.findIssuersForProviderOf(o, map[string]bool{})
+}
+
+// findIssuersForProviderOf enqueues the issuers that reference the provider
+// object, directly or through composite providers that have it as a member.
+// visited holds the provider objects already looked at, as composites may
+// reference each other.
+func (r *CarbonIntensityIssuerReconciler) findIssuersForProviderOf(o client.Object, visited map[string]bool) []reconcile.Request {
+	kind := o.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" {
+		gvk, err := apiutil.GVKForObject(o, r.Scheme)
+		if err != nil {
+			return nil
+		}
+		kind = gvk.Kind
+	}
+
+	indexValue := providerRefIndexValue(kind, o.GetNamespace(), o.GetName())
+	if visited[indexValue] {
+		return nil
+	}
+	visited[indexValue] = true
+
+	issuers := &carbonv1beta1.CarbonIntensityIssuerList{}
+	err := r.List(context.Background(), issuers, client.MatchingFields{providerRefIndexKey: indexValue})
+	if err != nil {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(issuers.Items))
+	for _, issuer := range issuers.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&issuer)})
+	}
+
+	composites := &carbonv1alpha1.CompositeProviderList{}
+	if err := r.List(context.Background(), composites, client.MatchingFields{memberRefIndexKey: indexValue}); err == nil {
+		for i := range composites.Items {
+			requests = append(requests, r.findIssuersForProviderOf(&composites.Items[i], visited)...)
+		}
+	}
+
+	return requests
+}
+
+// findIssuersForSecret enqueues the issuers of every provider object that
+// references a Secret.
+func (r *CarbonIntensityIssuerReconciler) findIssuersForSecret(o client.Object) []reconcile.Request {
+	ctx := context.Background()
+	secretRef := client.MatchingFields{secretRefIndexKey: secretRefIndexValue(o.GetNamespace(), "", o.GetName())}
+
+	requests := make([]reconcile.Request, 0)
+
+	watttimes := &carbonv1alpha1.WattTimeList{}
+	if err := r.List(ctx, watttimes, secretRef); err == nil {
+		for i := range watttimes.Items {
+			requests = append(requests, r.findIssuersForProvider(&watttimes.Items[i])...)
+		}
+	}
+
+	electricityMaps := &carbonv1alpha1.ElectricityMapsList{}
+	if err := r.List(ctx, electricityMaps, secretRef); err == nil {
+		for i := range electricityMaps.Items {
+			requests = append(requests, r.findIssuersForProvider(&electricityMaps.Items[i])...)
+		}
+	}
+
+	genericHTTPs := &carbonv1alpha1.GenericHTTPList{}
+	if err := r.List(ctx, genericHTTPs, secretRef); err == nil {
+		for i := range genericHTTPs.Items {
+			requests = append(requests, r.findIssuersForProvider(&genericHTTPs.Items[i])...)
+		}
+	}
+
+	staticTables := &carbonv1alpha1.StaticTableList{}
+	if err := r.List(ctx, staticTables, secretRef); err == nil {
+		for i := range staticTables.Items {
+			requests = append(requests, r.findIssuersForProvider(&staticTables.Items[i])...)
+		}
+	}
+
+	prometheusQueries := &carbonv1alpha1.PrometheusQueryList{}
+	if err := r.List(ctx, prometheusQueries, secretRef); err == nil {
+		for i := range prometheusQueries.Items {
+			requests = append(requests, r.findIssuersForProvider(&prometheusQueries.Items[i])...)
+		}
+	}
+
+	entsoes := &carbonv1alpha1.ENTSOEList{}
+	if err := r.List(ctx, entsoes, secretRef); err == nil {
+		for i := range entsoes.Items {
+			requests = append(requests, r.findIssuersForProvider(&entsoes.Items[i])...)
+		}
+	}
+
+	eias := &carbonv1alpha1.EIAList{}
+	if err := r.List(ctx, eias, secretRef); err == nil {
+		for i := range eias.Items {
+			requests = append(requests, r.findIssuersForProvider(&eias.Items[i])...)
+		}
+	}
+
+	return requests
+}
+
+// findIssuersForConfigMap enqueues the issuers of every provider object that
+// reads its data from a ConfigMap.
+func (r *CarbonIntensityIssuerReconciler) findIssuersForConfigMap(o client.Object) []reconcile.Request {
+	configMapRef := client.MatchingFields{configMapRefIndexKey: secretRefIndexValue(o.GetNamespace(), "", o.GetName())}
+
+	staticTables := &carbonv1alpha1.StaticTableList{}
+	if err := r.List(context.Background(), staticTables, configMapRef); err != nil {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0)
+	for i := range staticTables.Items {
+		requests = append(requests, r.findIssuersForProvider(&staticTables.Items[i])...)
+	}
+
+	return requests
+}
+
+// findIssuersForEmissionFactorSet enqueues the issuers of every provider object
+// that takes its emission factors from an EmissionFactorSet.
+func (r *CarbonIntensityIssuerReconciler) findIssuersForEmissionFactorSet(o client.Object) []reconcile.Request {
+	ctx := context.Background()
+	emissionFactorSetRef := client.MatchingFields{emissionFactorSetRefIndexKey: o.GetName()}
+
+	requests := make([]reconcile.Request, 0)
+	entsoes := &carbonv1alpha1.ENTSOEList{}
+	if err := r.List(ctx, entsoes, emissionFactorSetRef); err == nil {
+		for i := range entsoes.Items {
+			requests = append(requests, r.findIssuersForProvider(&entsoes.Items[i])...)
+		}
+	}
+
+	eias := &carbonv1alpha1.EIAList{}
+	if err := r.List(ctx, eias, emissionFactorSetRef); err == nil {
+		for i := range eias.Items {
+			requests = append(requests, r.findIssuersForProvider(&eias.Items[i])...)
+		}
+	}
+
+	return requests
+}
+
 func (r *CarbonIntensityIssuerReconciler) updateStatus(
 	ctx context.Context,
-	current *carbonv1alpha1.CarbonIntensityIssuer,
-	desired *carbonv1alpha1.CarbonIntensityIssuer,
+	current *carbonv1beta1.CarbonIntensityIssuer,
+	desired *carbonv1beta1.CarbonIntensityIssuer,
 ) (ctrl.Result, error) {
 	if !reflect.DeepEqual(current, desired) {
 		err := r.Status().Update(ctx, desired)
@@ -223,58 +702,271 @@ func (r *CarbonIntensityIssuerReconciler) updateStatus(
 	return ctrl.Result{}, nil
 }
 
+// publishForecast creates or updates the forecast ConfigMap of the issuer.
+func (r *CarbonIntensityIssuerReconciler) publishForecast(
+	ctx context.Context,
+	issuer *carbonv1beta1.CarbonIntensityIssuer,
+	forecast *common.Forecast,
+	zone string,
+	providerType providers.ProviderType,
+	pointTime time.Time,
+) error {
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(issuer)}
+	desired, err := r.prepareConfigMap(req, forecast, zone, pointTime, providerType)
+	if err != nil {
+		return err
+	}
+
+	return r.applyConfigMap(ctx, issuer, desired)
+}
+
+// applyConfigMap creates or updates the given ConfigMap and makes the issuer
+// its controller, so that it is garbage collected together with it.
+func (r *CarbonIntensityIssuerReconciler) applyConfigMap(
+	ctx context.Context,
+	issuer *carbonv1beta1.CarbonIntensityIssuer,
+	desired *corev1.ConfigMap,
+) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      desired.Name,
+			Namespace: desired.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Labels = desired.Labels
+		configMap.Data = desired.Data
+		configMap.BinaryData = nil
+
+		return controllerutil.SetControllerReference(issuer, configMap, r.Scheme)
+	})
+
+	return err
+}
+
 func (r *CarbonIntensityIssuerReconciler) prepareConfigMap(
 	req ctrl.Request,
-	forecast map[time.Time]float64,
+	forecast *common.Forecast,
 	zone string,
 	pointTime time.Time,
 	providerType providers.ProviderType,
-	immutable bool,
 ) (*corev1.ConfigMap, error) {
+	points := make([]forecastPoint, 0, len(forecast.Points))
+	for _, point := range forecast.Points {
+		points = append(points, forecastPoint{PointTime: point.PointTime, CarbonIntensity: point.Value})
+	}
 
-	jsonData, err := json.Marshal(forecast)
-	if err != nil {
-		return nil, err
+	payload := forecastPayload{
+		Version:       forecastConfigMapVersion,
+		Provider:      string(providerType),
+		Zone:          zone,
+		PublishedAt:   pointTime,
+		GeneratedAt:   forecast.GeneratedAt,
+		Horizon:       forecast.Horizon.String(),
+		Resolution:    forecast.Resolution.String(),
+		Unit:          string(forecast.Unit),
+		SignalType:    string(forecast.SignalType),
+		EmissionsType: string(forecast.EmissionsType),
+		Forecast:      points,
 	}
 
-	var buffer bytes.Buffer
-	encoder := gob.NewEncoder(&buffer)
-	if err := encoder.Encode(jsonData); err != nil {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
 		return nil, err
 	}
 
 	data := map[string]string{
-		"provider":  string(providerType),
-		"zone":      zone,
-		"pointTime": pointTime.String(),
+		"provider":           string(providerType),
+		"zone":               zone,
+		"pointTime":          pointTime.Format(time.RFC3339),
+		forecastConfigMapKey: string(jsonData),
 	}
 
-	configMapName := fmt.Sprintf("%s-forecast", req.Name)
+	configMapName := getForecastConfigMapName(req.Name)
 
-	labels := map[string]string{
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configMapName,
+			Namespace: req.Namespace,
+			Labels: getConfigMapLabels(
+				configMapName,
+				"forecast",
+				req.Name,
+				providerType,
+				zone,
+				string(forecast.EmissionsType),
+			),
+		},
+		Data: data,
+	}
+
+	return configMap, nil
+}
+
+// getConfigMapLabels returns the labels of a ConfigMap the issuer publishes.
+func getConfigMapLabels(
+	configMapName string,
+	component string,
+	issuerName string,
+	providerType providers.ProviderType,
+	zone string,
+	emissionsType string,
+) map[string]string {
+	return map[string]string{
 		"app.kubernetes.io/name":       "carbonintensityissuer",
 		"app.kubernetes.io/instance":   configMapName,
-		"app.kubernetes.io/component":  "forecast",
+		"app.kubernetes.io/component":  component,
 		"app.kubernetes.io/part-of":    "carbon",
 		"app.kubernetes.io/managed-by": "controller",
 		"app.kubernetes.io/created-by": "carbon",
-		labelProviderInstance:          req.Name,
+		labelProviderInstance:          issuerName,
 		labelProviderType:              string(providerType),
 		labelProviderZone:              zone,
+		labelEmissionsType:             emissionsType,
 	}
+}
 
-	configMap := &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      configMapName,
-			Namespace: req.Namespace,
-			Labels:    labels,
-		},
-		Immutable: &immutable,
-		Data:      data,
-		BinaryData: map[string][]byte{
-			"BinaryData": buffer.Bytes(),
-		},
+// setPowerBreakdownStatus sets the power breakdown fields of the status, or
+// clears them if there is no breakdown.
+func setPowerBreakdownStatus(status *carbonv1beta1.CarbonIntensityIssuerStatus, breakdown *common.PowerBreakdown) {
+	status.RenewablePercentage = nil
+	status.FossilFreePercentage = nil
+	status.ProductionMix = nil
+	status.PowerBreakdownObservedAt = nil
+
+	if breakdown == nil {
+		return
 	}
 
-	return configMap, nil
+	if breakdown.RenewablePercentage != nil {
+		renewablePercentage := fmt.Sprintf("%.2f", *breakdown.RenewablePercentage)
+		status.RenewablePercentage = &renewablePercentage
+	}
+
+	if breakdown.FossilFreePercentage != nil {
+		fossilFreePercentage := fmt.Sprintf("%.2f", *breakdown.FossilFreePercentage)
+		status.FossilFreePercentage = &fossilFreePercentage
+	}
+
+	sources := make([]string, 0, len(breakdown.Production))
+	for source := range breakdown.Production {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	for _, source := range sources {
+		powerSource := carbonv1beta1.PowerSource{
+			Source:     source,
+			Production: fmt.Sprintf("%.2f", breakdown.Production[source]),
+		}
+		if breakdown.ProductionTotal > 0 {
+			powerSource.Percentage = fmt.Sprintf("%.2f", breakdown.Production[source]/breakdown.ProductionTotal*100)
+		}
+
+		status.ProductionMix = append(status.ProductionMix, powerSource)
+	}
+
+	if !breakdown.PointTime.IsZero() {
+		status.PowerBreakdownObservedAt = &metav1.Time{Time: breakdown.PointTime}
+	}
+}
+
+// deleteIssuerMetrics deletes every series of the issuer, given as
+// namespace/name, from the gauges of the live carbon intensity and power
+// breakdown.
+func deleteIssuerMetrics(issuer string) {
+	labels := prometheus.Labels{"issuer": issuer}
+	metrics.CipLiveCarbonIntensityMetric.DeletePartialMatch(labels)
+	metrics.CipLiveRenewablePercentageMetric.DeletePartialMatch(labels)
+	metrics.CipLiveFossilFreePercentageMetric.DeletePartialMatch(labels)
+	metrics.CipLivePowerProductionMetric.DeletePartialMatch(labels)
+}
+
+// publishPowerBreakdownMetrics sets the power breakdown gauges of the issuer;
+// the series of sources or shares that are no longer reported are dropped by
+// deleteIssuerMetrics beforehand.
+func publishPowerBreakdownMetrics(providerKind string, issuer string, zone string, breakdown *common.PowerBreakdown) {
+	labels := prometheus.Labels{"provider": providerKind, "issuer": issuer, "zone": zone}
+	if breakdown == nil {
+		return
+	}
+
+	if breakdown.RenewablePercentage != nil {
+		metrics.CipLiveRenewablePercentageMetric.With(labels).Set(*breakdown.RenewablePercentage)
+	}
+
+	if breakdown.FossilFreePercentage != nil {
+		metrics.CipLiveFossilFreePercentageMetric.With(labels).Set(*breakdown.FossilFreePercentage)
+	}
+
+	for source, production := range breakdown.Production {
+		metrics.CipLivePowerProductionMetric.WithLabelValues(providerKind, issuer, zone, source).Set(production)
+	}
+}
+
+// resolveEmissionsType returns the requested emissions type, or the default of
+// the provider if none was requested, as long as the provider supports it.
+func resolveEmissionsType(requested string, capabilities *common.Capabilities) (providers.EmissionsType, error) {
+	supported := capabilities.EmissionsTypes
+	if requested == "" {
+		if len(supported) == 0 {
+			return "", fmt.Errorf("provider does not report any emissions type")
+		}
+
+		return supported[0], nil
+	}
+
+	emissionsType := providers.EmissionsType(requested)
+	if !capabilities.SupportsEmissionsType(emissionsType) {
+		return "", fmt.Errorf("emissions type '%s' is not supported by the provider, supported: %v", requested, supported)
+	}
+
+	return emissionsType, nil
+}
+
+// resolveZone returns the zone the location of the issuer lies in. The zone in
+// the status is reused as long as it was resolved for the current generation of
+// the issuer and its refresh interval has not elapsed; resolved reports whether
+// the provider was asked.
+func resolveZone(
+	ctx context.Context,
+	issuer *carbonv1beta1.CarbonIntensityIssuer,
+	zoneCondition *metav1.Condition,
+	provider providers.Provider,
+) (zone string, resolved bool, err error) {
+	location := issuer.Spec.Location
+
+	refreshInterval := defaultZoneRefreshInterval
+	if location.RefreshInterval != nil && location.RefreshInterval.Duration > 0 {
+		refreshInterval = location.RefreshInterval.Duration
+	}
+
+	if issuer.Status.Zone != "" && issuer.Status.ZoneResolvedAt != nil &&
+		zoneCondition != nil && zoneCondition.Reason == carbonv1beta1.ZoneResolved &&
+		zoneCondition.ObservedGeneration == issuer.Generation &&
+		issuer.Status.ZoneResolvedAt.Add(refreshInterval).After(time.Now()) {
+		return issuer.Status.Zone, false, nil
+	}
+
+	resolver, ok := provider.(providers.ZoneResolver)
+	if !ok {
+		return "", false, errZoneResolutionUnsupported
+	}
+
+	latitude, longitude, err := location.Coordinates()
+	if err != nil {
+		return "", false, err
+	}
+
+	z, err := resolver.GetZoneFromLocation(ctx, latitude, longitude)
+	if err != nil {
+		return "", false, err
+	}
+
+	return z.Name, true, nil
+}
+
+func getForecastConfigMapName(issuerName string) string {
+	return fmt.Sprintf("%s-forecast", issuerName)
 }
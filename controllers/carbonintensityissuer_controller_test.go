@@ -0,0 +1,134 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	corev1beta1 "github.com/rekuberate-io/carbon/api/v1beta1"
+)
+
+var _ = Describe("CarbonIntensityIssuer controller", func() {
+	const (
+		namespace = "default"
+		timeout   = 10 * time.Second
+		interval  = 250 * time.Millisecond
+	)
+
+	table := func(value string) map[string]string {
+		return map[string]string{"table.csv": "timestamp,carbonIntensity,zone\n" +
+			"2023-01-02T00:00:00Z," + value + ",site-a\n" +
+			"2023-01-02T12:00:00Z,190,site-a\n"}
+	}
+
+	It("publishes the forecast of the issuer to a ConfigMap it owns", func() {
+		ctx := context.Background()
+
+		tableConfigMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "statictable-forecast-table"},
+			Data:       table("310"),
+		}
+		Expect(k8sClient.Create(ctx, tableConfigMap)).To(Succeed())
+
+		staticTable := &corev1alpha1.StaticTable{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "statictable-forecast"},
+			Spec: corev1alpha1.StaticTableSpec{
+				Source: corev1alpha1.StaticTableSource{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: tableConfigMap.Name},
+						Key:                  "table.csv",
+					},
+				},
+				Format:          "csv",
+				Repeat:          "daily",
+				Resolution:      &metav1.Duration{Duration: 12 * time.Hour},
+				ForecastHorizon: &metav1.Duration{Duration: 24 * time.Hour},
+			},
+		}
+		Expect(k8sClient.Create(ctx, staticTable)).To(Succeed())
+
+		issuer := &corev1beta1.CarbonIntensityIssuer{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "issuer-forecast"},
+			Spec: corev1beta1.CarbonIntensityIssuerSpec{
+				ForecastRefreshInterval: metav1.Duration{Duration: 12 * time.Hour},
+				LiveRefreshInterval:     metav1.Duration{Duration: 12 * time.Hour},
+				Zone:                    "site-a",
+				ProviderRef:             &corev1.ObjectReference{Kind: "StaticTable", Name: staticTable.Name},
+			},
+		}
+		Expect(k8sClient.Create(ctx, issuer)).To(Succeed())
+
+		reconciler := &CarbonIntensityIssuerReconciler{
+			Client:   k8sClient,
+			Scheme:   scheme.Scheme,
+			Recorder: record.NewFakeRecorder(100),
+		}
+		req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(issuer)}
+
+		// the first reconcile may race its own status update, retry until it
+		// goes through
+		reconcile := func() error {
+			_, err := reconciler.Reconcile(ctx, req)
+			return err
+		}
+
+		By("creating the forecast ConfigMap")
+		Eventually(reconcile, timeout, interval).Should(Succeed())
+
+		forecastConfigMap := &corev1.ConfigMap{}
+		forecastObjectKey := client.ObjectKey{Namespace: namespace, Name: getForecastConfigMapName(issuer.Name)}
+		Expect(k8sClient.Get(ctx, forecastObjectKey, forecastConfigMap)).To(Succeed())
+		Expect(forecastConfigMap.Data).To(HaveKey(forecastConfigMapKey))
+		Expect(forecastConfigMap.Data).To(HaveKeyWithValue("zone", "site-a"))
+
+		Expect(k8sClient.Get(ctx, req.NamespacedName, issuer)).To(Succeed())
+		owner := metav1.GetControllerOf(forecastConfigMap)
+		Expect(owner).NotTo(BeNil())
+		Expect(owner.Kind).To(Equal("CarbonIntensityIssuer"))
+		Expect(owner.UID).To(Equal(issuer.UID))
+		Expect(issuer.Status.LastForecast).NotTo(BeNil())
+
+		By("updating the forecast ConfigMap on the next reconcile")
+		tableConfigMap.Data = table("320")
+		Expect(k8sClient.Update(ctx, tableConfigMap)).To(Succeed())
+
+		// make the forecast due again rather than waiting for its refresh
+		// interval
+		issuer.Status.LastForecast = &metav1.Time{Time: time.Now().Add(-24 * time.Hour)}
+		Expect(k8sClient.Status().Update(ctx, issuer)).To(Succeed())
+
+		Eventually(reconcile, timeout, interval).Should(Succeed())
+
+		updated := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, forecastObjectKey, updated)).To(Succeed())
+		Expect(updated.UID).To(Equal(forecastConfigMap.UID))
+		Expect(updated.ResourceVersion).NotTo(Equal(forecastConfigMap.ResourceVersion))
+		Expect(updated.Data[forecastConfigMapKey]).NotTo(Equal(forecastConfigMap.Data[forecastConfigMapKey]))
+	})
+})
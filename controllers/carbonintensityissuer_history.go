@@ -0,0 +1,252 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/rekuberate-io/carbon/pkg/common"
+	"github.com/rekuberate-io/carbon/pkg/providers"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sort"
+	"time"
+
+	carbonv1beta1 "github.com/rekuberate-io/carbon/api/v1beta1"
+)
+
+const (
+	historyConfigMapKey     = "history.json"
+	historyConfigMapVersion = "v1"
+
+	defaultHistoryBackfill  = 24 * time.Hour
+	defaultHistoryRetention = 7 * 24 * time.Hour
+
+	// historyBackfillRetryInterval is the time a failed backfill is retried
+	// after, unless the issuer changes in the meantime
+	historyBackfillRetryInterval = time.Hour
+)
+
+// historyPayload is the document published under historyConfigMapKey in the
+// history ConfigMap of every issuer. Consumers should check Version before
+// decoding the rest of the payload.
+type historyPayload struct {
+	Version       string         `json:"version"`
+	Provider      string         `json:"provider"`
+	Zone          string         `json:"zone"`
+	Unit          string         `json:"unit"`
+	SignalType    string         `json:"signalType"`
+	EmissionsType string         `json:"emissionsType"`
+	Points        []historyPoint `json:"points"`
+}
+
+type historyPoint struct {
+	PointTime       time.Time `json:"pointTime"`
+	CarbonIntensity float64   `json:"carbonIntensity"`
+	IsEstimated     bool      `json:"isEstimated,omitempty"`
+}
+
+// updateHistory adds the reading to the history ConfigMap of the issuer and
+// drops the points older than the retention of the issuer. The history is
+// backfilled from the provider first, if the provider serves past carbon
+// intensities and the history was not backfilled yet; a failed backfill is
+// retried after historyBackfillRetryInterval, without holding back the reading.
+// The history starts over once the provider, zone, unit or emissions type of
+// the readings changes.
+func (r *CarbonIntensityIssuerReconciler) updateHistory(
+	ctx context.Context,
+	issuer *carbonv1beta1.CarbonIntensityIssuer,
+	status *carbonv1beta1.CarbonIntensityIssuerStatus,
+	provider providers.Provider,
+	providerType providers.ProviderType,
+	zone string,
+	reading *common.Reading,
+) error {
+	backfill, retention := getHistorySettings(issuer)
+	configMapName := getHistoryConfigMapName(issuer.Name)
+
+	configMap := &corev1.ConfigMap{}
+	configMapMissing := false
+	if err := r.Get(ctx, client.ObjectKey{Namespace: issuer.Namespace, Name: configMapName}, configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		configMapMissing = true
+	}
+
+	if retention == 0 {
+		status.HistoryBackfilledAt = nil
+		meta.RemoveStatusCondition(&status.Conditions, carbonv1beta1.ConditionHistoryBackfilled.Type)
+		if configMapMissing {
+			return nil
+		}
+
+		return client.IgnoreNotFound(r.Delete(ctx, configMap))
+	}
+
+	// the points of another signal are useless, start over and backfill the
+	// history of the new one
+	payload := historyPayload{}
+	if data, ok := configMap.Data[historyConfigMapKey]; ok {
+		if err := json.Unmarshal([]byte(data), &payload); err != nil ||
+			payload.Provider != string(providerType) ||
+			payload.Zone != zone ||
+			payload.Unit != string(reading.Unit) ||
+			payload.EmissionsType != string(reading.EmissionsType) {
+			payload = historyPayload{}
+			status.HistoryBackfilledAt = nil
+			meta.RemoveStatusCondition(&status.Conditions, carbonv1beta1.ConditionHistoryBackfilled.Type)
+		}
+	}
+
+	points := map[int64]historyPoint{}
+	for _, point := range payload.Points {
+		points[point.PointTime.Unix()] = point
+	}
+
+	now := time.Now()
+	historyProvider, ok := provider.(providers.HistoryProvider)
+	if ok && backfill > 0 && (status.HistoryBackfilledAt == nil || configMapMissing) && backfillDue(issuer, status, now) {
+		condition := carbonv1beta1.ConditionHistoryBackfilled.DeepCopy()
+		condition.ObservedGeneration = issuer.Generation
+
+		readings, err := historyProvider.GetHistory(ctx, zone, now.Add(-backfill), now)
+		if err != nil {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = carbonv1beta1.HistoryBackfillFailed
+			condition.Message = fmt.Sprintf("Unable to backfill the history of zone '%s': %s", zone, err)
+
+			// the transition time of a failed backfill is the time of its
+			// latest attempt, the retry interval counts from it
+			meta.RemoveStatusCondition(&status.Conditions, condition.Type)
+
+			logger.Error(err, "unable to backfill history", "providerType", providerType, "zone", zone)
+			r.Recorder.Event(issuer, corev1.EventTypeWarning, condition.Reason, condition.Message)
+		} else {
+			for _, past := range readings {
+				points[past.PointTime.Unix()] = historyPoint{
+					PointTime:       past.PointTime,
+					CarbonIntensity: past.Value,
+					IsEstimated:     past.IsEstimated,
+				}
+			}
+
+			status.HistoryBackfilledAt = &metav1.Time{Time: now}
+
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = carbonv1beta1.HistoryBackfilled
+			condition.Message = fmt.Sprintf("Backfilled %d points of the history of zone '%s'", len(readings), zone)
+		}
+
+		meta.SetStatusCondition(&status.Conditions, *condition)
+	}
+
+	if !reading.PointTime.IsZero() {
+		points[reading.PointTime.Unix()] = historyPoint{
+			PointTime:       reading.PointTime,
+			CarbonIntensity: reading.Value,
+			IsEstimated:     reading.IsEstimated,
+		}
+	}
+
+	payload = historyPayload{
+		Version:       historyConfigMapVersion,
+		Provider:      string(providerType),
+		Zone:          zone,
+		Unit:          string(reading.Unit),
+		SignalType:    string(reading.SignalType),
+		EmissionsType: string(reading.EmissionsType),
+		Points:        make([]historyPoint, 0, len(points)),
+	}
+
+	oldest := now.Add(-retention)
+	for _, point := range points {
+		if point.PointTime.Before(oldest) {
+			continue
+		}
+
+		payload.Points = append(payload.Points, point)
+	}
+
+	sort.Slice(payload.Points, func(i, j int) bool {
+		return payload.Points[i].PointTime.Before(payload.Points[j].PointTime)
+	})
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	desired := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configMapName,
+			Namespace: issuer.Namespace,
+			Labels: getConfigMapLabels(
+				configMapName,
+				"history",
+				issuer.Name,
+				providerType,
+				zone,
+				string(reading.EmissionsType),
+			),
+		},
+		Data: map[string]string{
+			"provider":          string(providerType),
+			"zone":              zone,
+			historyConfigMapKey: string(jsonData),
+		},
+	}
+
+	return r.applyConfigMap(ctx, issuer, desired)
+}
+
+// backfillDue reports whether the history of the issuer may be backfilled,
+// which after a failed backfill is only once the issuer changed or the retry
+// interval elapsed.
+func backfillDue(issuer *carbonv1beta1.CarbonIntensityIssuer, status *carbonv1beta1.CarbonIntensityIssuerStatus, now time.Time) bool {
+	condition := meta.FindStatusCondition(status.Conditions, carbonv1beta1.ConditionHistoryBackfilled.Type)
+	if condition == nil || condition.Reason != carbonv1beta1.HistoryBackfillFailed || condition.ObservedGeneration != issuer.Generation {
+		return true
+	}
+
+	return !now.Before(condition.LastTransitionTime.Add(historyBackfillRetryInterval))
+}
+
+// getHistorySettings returns the backfill and retention of the history of the
+// issuer, or their defaults if the issuer does not configure its history.
+func getHistorySettings(issuer *carbonv1beta1.CarbonIntensityIssuer) (backfill time.Duration, retention time.Duration) {
+	if issuer.Spec.History == nil {
+		return defaultHistoryBackfill, defaultHistoryRetention
+	}
+
+	backfill = issuer.Spec.History.Backfill.Duration
+	retention = issuer.Spec.History.Retention.Duration
+	if backfill > retention {
+		backfill = retention
+	}
+
+	return backfill, retention
+}
+
+func getHistoryConfigMapName(issuerName string) string {
+	return fmt.Sprintf("%s-history", issuerName)
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/rekuberate-io/carbon/pkg/common"
+	"github.com/rekuberate-io/carbon/pkg/providers"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	carbonv1beta1 "github.com/rekuberate-io/carbon/api/v1beta1"
+)
+
+// historyProvider serves a single past reading of its zone, or fails with err.
+type historyProvider struct {
+	providers.Provider
+	past     common.Reading
+	err      error
+	requests int
+}
+
+func (p *historyProvider) GetHistory(ctx context.Context, zone string, start time.Time, end time.Time) ([]common.Reading, error) {
+	p.requests++
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return []common.Reading{p.past}, nil
+}
+
+func TestBackfillDue(t *testing.T) {
+	now := time.Now()
+	failedAt := func(ago time.Duration, generation int64) []metav1.Condition {
+		return []metav1.Condition{{
+			Type:               carbonv1beta1.ConditionHistoryBackfilled.Type,
+			Status:             metav1.ConditionFalse,
+			Reason:             carbonv1beta1.HistoryBackfillFailed,
+			ObservedGeneration: generation,
+			LastTransitionTime: metav1.Time{Time: now.Add(-ago)},
+		}}
+	}
+
+	tests := []struct {
+		name       string
+		conditions []metav1.Condition
+		want       bool
+	}{
+		{name: "never backfilled", want: true},
+		{name: "backfilled", conditions: []metav1.Condition{{
+			Type:               carbonv1beta1.ConditionHistoryBackfilled.Type,
+			Status:             metav1.ConditionTrue,
+			Reason:             carbonv1beta1.HistoryBackfilled,
+			ObservedGeneration: 1,
+			LastTransitionTime: metav1.Time{Time: now},
+		}}, want: true},
+		{name: "failed recently", conditions: failedAt(time.Minute, 1), want: false},
+		{name: "failed a retry interval ago", conditions: failedAt(historyBackfillRetryInterval, 1), want: true},
+		{name: "failed for a former generation", conditions: failedAt(time.Minute, 0), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := &carbonv1beta1.CarbonIntensityIssuer{ObjectMeta: metav1.ObjectMeta{Generation: 1}}
+			status := &carbonv1beta1.CarbonIntensityIssuerStatus{Conditions: tt.conditions}
+
+			if got := backfillDue(issuer, status, now); got != tt.want {
+				t.Errorf("backfillDue = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateHistory(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	backfilledAt := metav1.Time{Time: now.Add(-time.Hour)}
+
+	// the history of the issuer holds a point within the default retention and
+	// one past it
+	stored := historyPayload{
+		Version:       historyConfigMapVersion,
+		Provider:      string(providers.WattTime),
+		Zone:          "CAISO_NORTH",
+		Unit:          string(common.GramsPerKilowattHour),
+		EmissionsType: string(common.Marginal),
+		Points: []historyPoint{
+			{PointTime: now.Add(-defaultHistoryRetention - time.Hour), CarbonIntensity: 100},
+			{PointTime: now.Add(-time.Hour), CarbonIntensity: 200},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		providerType providers.ProviderType
+		unit         common.Unit
+		emissions    common.EmissionsType
+		generation   int64
+		// backfilled reports whether the history is expected to start over
+		// from the provider
+		backfilled bool
+	}{
+		{name: "trims to retention", providerType: providers.WattTime, unit: common.GramsPerKilowattHour, emissions: common.Marginal, generation: 1},
+		{name: "keeps history when only the interval changes", providerType: providers.WattTime, unit: common.GramsPerKilowattHour, emissions: common.Marginal, generation: 2},
+		{name: "resets on another provider", providerType: providers.ElectricityMaps, unit: common.GramsPerKilowattHour, emissions: common.Marginal, generation: 1, backfilled: true},
+		{name: "resets on another unit", providerType: providers.WattTime, unit: common.Unit("lbs/MWh"), emissions: common.Marginal, generation: 1, backfilled: true},
+		{name: "resets on another emissions type", providerType: providers.WattTime, unit: common.GramsPerKilowattHour, emissions: common.Average, generation: 1, backfilled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := &carbonv1beta1.CarbonIntensityIssuer{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "issuer-sample", Generation: tt.generation},
+				Spec: carbonv1beta1.CarbonIntensityIssuerSpec{
+					LiveRefreshInterval: metav1.Duration{Duration: time.Duration(tt.generation) * 5 * time.Minute},
+					Zone:                "CAISO_NORTH",
+				},
+			}
+
+			data, err := json.Marshal(stored)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := newHistoryTestReconciler(t, issuer, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: getHistoryConfigMapName(issuer.Name)},
+				Data:       map[string]string{historyConfigMapKey: string(data)},
+			})
+
+			reading := &common.Reading{
+				Zone:          "CAISO_NORTH",
+				Value:         300,
+				Unit:          tt.unit,
+				EmissionsType: tt.emissions,
+				PointTime:     now,
+			}
+			provider := &historyProvider{past: common.Reading{PointTime: now.Add(-2 * time.Hour), Value: 150}}
+			status := &carbonv1beta1.CarbonIntensityIssuerStatus{HistoryBackfilledAt: &backfilledAt}
+
+			err = r.updateHistory(context.Background(), issuer, status, provider, tt.providerType, "CAISO_NORTH", reading)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := []float64{200, 300}
+			if tt.backfilled {
+				want = []float64{150, 300}
+			}
+
+			payload := getHistoryPayload(t, r, issuer)
+			if got := historyValues(payload); !equalValues(got, want) {
+				t.Errorf("history = %v, want %v", got, want)
+			}
+			if (provider.requests > 0) != tt.backfilled {
+				t.Errorf("history was requested %d times, want backfill %t", provider.requests, tt.backfilled)
+			}
+			if payload.Provider != string(tt.providerType) || payload.Unit != string(tt.unit) || payload.EmissionsType != string(tt.emissions) {
+				t.Errorf("history of %s in %s (%s), want the signal of the reading", payload.Provider, payload.Unit, payload.EmissionsType)
+			}
+			if tt.backfilled && status.HistoryBackfilledAt.Equal(&backfilledAt) {
+				t.Error("backfill time was kept, want the time of the new backfill")
+			}
+		})
+	}
+}
+
+func TestUpdateHistoryRetriesFailedBackfill(t *testing.T) {
+	issuer := &carbonv1beta1.CarbonIntensityIssuer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "issuer-sample", Generation: 1},
+		Spec:       carbonv1beta1.CarbonIntensityIssuerSpec{Zone: "CAISO_NORTH"},
+	}
+	r := newHistoryTestReconciler(t, issuer)
+
+	reading := &common.Reading{Zone: "CAISO_NORTH", Value: 300, PointTime: time.Now()}
+	provider := &historyProvider{err: errors.New("unavailable")}
+	status := &carbonv1beta1.CarbonIntensityIssuerStatus{}
+
+	for i := 0; i < 2; i++ {
+		err := r.updateHistory(context.Background(), issuer, status, provider, providers.WattTime, "CAISO_NORTH", reading)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if provider.requests != 1 {
+		t.Errorf("history was requested %d times, want no retry within %s", provider.requests, historyBackfillRetryInterval)
+	}
+
+	condition := meta.FindStatusCondition(status.Conditions, carbonv1beta1.ConditionHistoryBackfilled.Type)
+	if condition == nil || condition.Reason != carbonv1beta1.HistoryBackfillFailed {
+		t.Errorf("condition = %v, want %s", condition, carbonv1beta1.HistoryBackfillFailed)
+	}
+	if got := historyValues(getHistoryPayload(t, r, issuer)); !equalValues(got, []float64{300}) {
+		t.Errorf("history = %v, want the reading despite the failed backfill", got)
+	}
+}
+
+func newHistoryTestReconciler(t *testing.T, objects ...client.Object) *CarbonIntensityIssuerReconciler {
+	logger = logr.Discard()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := carbonv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	return &CarbonIntensityIssuerReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+}
+
+func getHistoryPayload(t *testing.T, r *CarbonIntensityIssuerReconciler, issuer *carbonv1beta1.CarbonIntensityIssuer) historyPayload {
+	configMap := &corev1.ConfigMap{}
+	objectKey := client.ObjectKey{Namespace: issuer.Namespace, Name: getHistoryConfigMapName(issuer.Name)}
+	if err := r.Get(context.Background(), objectKey, configMap); err != nil {
+		t.Fatal(err)
+	}
+
+	var payload historyPayload
+	if err := json.Unmarshal([]byte(configMap.Data[historyConfigMapKey]), &payload); err != nil {
+		t.Fatal(err)
+	}
+
+	return payload
+}
+
+func historyValues(payload historyPayload) []float64 {
+	values := make([]float64, 0, len(payload.Points))
+	for _, point := range payload.Points {
+		values = append(values, point.CarbonIntensity)
+	}
+
+	return values
+}
+
+func equalValues(a []float64, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
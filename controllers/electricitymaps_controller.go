@@ -0,0 +1,55 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+)
+
+// ElectricityMapsReconciler reconciles a ElectricityMaps object
+type ElectricityMapsReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=core.rekuberate.io,resources=electricitymaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core.rekuberate.io,resources=electricitymaps/status,verbs=get;update;patch
+
+// Reconcile checks the ElectricityMaps provider and reports its health in the status
+// of the object.
+func (r *ElectricityMapsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return r.statusReconciler().reconcileProvider(ctx, req, &carbonv1alpha1.ElectricityMaps{}, func(o client.Object) *carbonv1alpha1.ProviderStatus {
+		return &o.(*carbonv1alpha1.ElectricityMaps).Status.ProviderStatus
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElectricityMapsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return r.statusReconciler().setupWithManager(mgr, &carbonv1alpha1.ElectricityMaps{}, &carbonv1alpha1.ElectricityMapsList{}, r)
+}
+
+func (r *ElectricityMapsReconciler) statusReconciler() *providerStatusReconciler {
+	return &providerStatusReconciler{Client: r.Client, Recorder: r.Recorder, kind: "ElectricityMaps"}
+}
@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	carbonv1beta1 "github.com/rekuberate-io/carbon/api/v1beta1"
+)
+
+const (
+	// providerRefIndexKey indexes issuers by the provider object they reference
+	providerRefIndexKey = ".spec.providerRef"
+	// secretRefIndexKey indexes provider objects by the Secret they reference
+	secretRefIndexKey = ".spec.secretRef"
+	// configMapRefIndexKey indexes provider objects by the ConfigMap they
+	// reference
+	configMapRefIndexKey = ".spec.configMapRef"
+	// memberRefIndexKey indexes composite provider objects by the provider
+	// objects of their members
+	memberRefIndexKey = ".spec.members.providerRef"
+	// emissionFactorSetRefIndexKey indexes provider objects by the
+	// EmissionFactorSet they reference
+	emissionFactorSetRefIndexKey = ".spec.emissionFactorSetRef"
+)
+
+// SetupIndexes registers the field indexes the controllers use to find the
+// issuers that depend on a provider object, and the provider objects that
+// depend on a Secret.
+func SetupIndexes(ctx context.Context, mgr ctrl.Manager) error {
+	indexer := mgr.GetFieldIndexer()
+
+	err := indexer.IndexField(ctx, &carbonv1beta1.CarbonIntensityIssuer{}, providerRefIndexKey, func(o client.Object) []string {
+		issuer := o.(*carbonv1beta1.CarbonIntensityIssuer)
+		providerRef := issuer.Spec.ProviderRef
+		if providerRef == nil || providerRef.Name == "" {
+			return nil
+		}
+
+		namespace := providerRef.Namespace
+		if namespace == "" {
+			namespace = issuer.Namespace
+		}
+
+		return []string{providerRefIndexValue(providerRef.Kind, namespace, providerRef.Name)}
+	})
+	if err != nil {
+		return err
+	}
+
+	err = indexer.IndexField(ctx, &carbonv1alpha1.WattTime{}, secretRefIndexKey, func(o client.Object) []string {
+		watttime := o.(*carbonv1alpha1.WattTime)
+		if watttime.Spec.Password == nil {
+			return nil
+		}
+
+		return []string{secretRefIndexValue(watttime.Spec.Password.Namespace, watttime.Namespace, watttime.Spec.Password.Name)}
+	})
+	if err != nil {
+		return err
+	}
+
+	err = indexer.IndexField(ctx, &carbonv1alpha1.ElectricityMaps{}, secretRefIndexKey, func(o client.Object) []string {
+		electricityMaps := o.(*carbonv1alpha1.ElectricityMaps)
+		if electricityMaps.Spec.ApiKeyRef == nil {
+			return nil
+		}
+
+		return []string{secretRefIndexValue(electricityMaps.Spec.ApiKeyRef.Namespace, electricityMaps.Namespace, electricityMaps.Spec.ApiKeyRef.Name)}
+	})
+	if err != nil {
+		return err
+	}
+
+	err = indexer.IndexField(ctx, &carbonv1alpha1.GenericHTTP{}, secretRefIndexKey, func(o client.Object) []string {
+		genericHTTP := o.(*carbonv1alpha1.GenericHTTP)
+		if genericHTTP.Spec.Auth == nil || genericHTTP.Spec.Auth.SecretRef == nil {
+			return nil
+		}
+
+		secretRef := genericHTTP.Spec.Auth.SecretRef
+		return []string{secretRefIndexValue(secretRef.Namespace, genericHTTP.Namespace, secretRef.Name)}
+	})
+	if err != nil {
+		return err
+	}
+
+	err = indexer.IndexField(ctx, &carbonv1alpha1.StaticTable{}, secretRefIndexKey, func(o client.Object) []string {
+		staticTable := o.(*carbonv1alpha1.StaticTable)
+		if staticTable.Spec.Source.SecretKeyRef == nil {
+			return nil
+		}
+
+		return []string{secretRefIndexValue("", staticTable.Namespace, staticTable.Spec.Source.SecretKeyRef.Name)}
+	})
+	if err != nil {
+		return err
+	}
+
+	err = indexer.IndexField(ctx, &carbonv1alpha1.StaticTable{}, configMapRefIndexKey, func(o client.Object) []string {
+		staticTable := o.(*carbonv1alpha1.StaticTable)
+		if staticTable.Spec.Source.ConfigMapKeyRef == nil {
+			return nil
+		}
+
+		return []string{secretRefIndexValue("", staticTable.Namespace, staticTable.Spec.Source.ConfigMapKeyRef.Name)}
+	})
+	if err != nil {
+		return err
+	}
+
+	err = indexer.IndexField(ctx, &carbonv1alpha1.PrometheusQuery{}, secretRefIndexKey, func(o client.Object) []string {
+		prometheusQuery := o.(*carbonv1alpha1.PrometheusQuery)
+		if prometheusQuery.Spec.Auth == nil || prometheusQuery.Spec.Auth.SecretRef == nil {
+			return nil
+		}
+
+		secretRef := prometheusQuery.Spec.Auth.SecretRef
+		return []string{secretRefIndexValue(secretRef.Namespace, prometheusQuery.Namespace, secretRef.Name)}
+	})
+	if err != nil {
+		return err
+	}
+
+	err = indexer.IndexField(ctx, &carbonv1alpha1.ENTSOE{}, secretRefIndexKey, func(o client.Object) []string {
+		entsoe := o.(*carbonv1alpha1.ENTSOE)
+		if entsoe.Spec.SecurityTokenRef == nil {
+			return nil
+		}
+
+		secretRef := entsoe.Spec.SecurityTokenRef
+		return []string{secretRefIndexValue(secretRef.Namespace, entsoe.Namespace, secretRef.Name)}
+	})
+	if err != nil {
+		return err
+	}
+
+	err = indexer.IndexField(ctx, &carbonv1alpha1.EIA{}, secretRefIndexKey, func(o client.Object) []string {
+		eia := o.(*carbonv1alpha1.EIA)
+		if eia.Spec.ApiKeyRef == nil {
+			return nil
+		}
+
+		secretRef := eia.Spec.ApiKeyRef
+		return []string{secretRefIndexValue(secretRef.Namespace, eia.Namespace, secretRef.Name)}
+	})
+	if err != nil {
+		return err
+	}
+
+	err = indexer.IndexField(ctx, &carbonv1alpha1.ENTSOE{}, emissionFactorSetRefIndexKey, func(o client.Object) []string {
+		entsoe := o.(*carbonv1alpha1.ENTSOE)
+		if entsoe.Spec.EmissionFactorSetRef == nil {
+			return nil
+		}
+
+		return []string{entsoe.Spec.EmissionFactorSetRef.Name}
+	})
+	if err != nil {
+		return err
+	}
+
+	err = indexer.IndexField(ctx, &carbonv1alpha1.EIA{}, emissionFactorSetRefIndexKey, func(o client.Object) []string {
+		eia := o.(*carbonv1alpha1.EIA)
+		if eia.Spec.EmissionFactorSetRef == nil {
+			return nil
+		}
+
+		return []string{eia.Spec.EmissionFactorSetRef.Name}
+	})
+	if err != nil {
+		return err
+	}
+
+	return indexer.IndexField(ctx, &carbonv1alpha1.CompositeProvider{}, memberRefIndexKey, func(o client.Object) []string {
+		composite := o.(*carbonv1alpha1.CompositeProvider)
+
+		values := make([]string, 0, len(composite.Spec.Members))
+		for _, member := range composite.Spec.Members {
+			providerRef := member.ProviderRef
+			if providerRef == nil || providerRef.Name == "" {
+				continue
+			}
+
+			namespace := providerRef.Namespace
+			if namespace == "" {
+				namespace = composite.Namespace
+			}
+
+			values = append(values, providerRefIndexValue(providerRef.Kind, namespace, providerRef.Name))
+		}
+
+		return values
+	})
+}
+
+func providerRefIndexValue(kind string, namespace string, name string) string {
+	return fmt.Sprintf("%s/%s/%s", strings.ToLower(kind), namespace, name)
+}
+
+// secretRefIndexValue returns the index value of a Secret (or ConfigMap)
+// reference, which defaults to the namespace of the referencing object.
+func secretRefIndexValue(namespace string, defaultNamespace string, name string) string {
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
@@ -27,12 +27,39 @@ var (
 			Name: "rekuberate_carbon_intensity_provider_live_gramsperkilowatthour",
 			Help: "Carbon Intensity (grCO2eq/KWh)",
 		},
+		[]string{"provider", "issuer", "zone", "emissions_type"},
+	)
+
+	CipLiveRenewablePercentageMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "rekuberate_carbon_intensity_provider_live_renewable_percentage",
+			Help: "Share of renewable sources in the electricity of the zone (%)",
+		},
 		[]string{"provider", "issuer", "zone"},
 	)
+
+	CipLiveFossilFreePercentageMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "rekuberate_carbon_intensity_provider_live_fossil_free_percentage",
+			Help: "Share of fossil-free sources in the electricity of the zone (%)",
+		},
+		[]string{"provider", "issuer", "zone"},
+	)
+
+	CipLivePowerProductionMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "rekuberate_carbon_intensity_provider_live_power_production_megawatts",
+			Help: "Power produced per source in the zone (MW)",
+		},
+		[]string{"provider", "issuer", "zone", "source"},
+	)
 )
 
 func init() {
 	metrics.Registry.MustRegister(CipReconciliationLoopsTotal)
 	metrics.Registry.MustRegister(CipReconciliationLoopErrorsTotal)
 	metrics.Registry.MustRegister(CipLiveCarbonIntensityMetric)
+	metrics.Registry.MustRegister(CipLiveRenewablePercentageMetric)
+	metrics.Registry.MustRegister(CipLiveFossilFreePercentageMetric)
+	metrics.Registry.MustRegister(CipLivePowerProductionMetric)
 }
@@ -0,0 +1,65 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rekuberate-io/carbon/controllers/metrics"
+	"github.com/rekuberate-io/carbon/pkg/common"
+)
+
+func TestDeleteIssuerMetrics(t *testing.T) {
+	renewable, fossilFree := 40.0, 60.0
+	breakdown := &common.PowerBreakdown{
+		RenewablePercentage:  &renewable,
+		FossilFreePercentage: &fossilFree,
+		Production:           map[string]float64{"wind": 100, "gas": 200},
+	}
+
+	// the issuer moved from one zone to another, next to an issuer that did not
+	for _, series := range []struct{ issuer, zone string }{
+		{issuer: "default/moved", zone: "DE"},
+		{issuer: "default/moved", zone: "FR"},
+		{issuer: "default/kept", zone: "DE"},
+	} {
+		metrics.CipLiveCarbonIntensityMetric.WithLabelValues("ElectricityMaps", series.issuer, series.zone, "average").Set(300)
+		publishPowerBreakdownMetrics("ElectricityMaps", series.issuer, series.zone, breakdown)
+	}
+	t.Cleanup(func() {
+		deleteIssuerMetrics("default/kept")
+	})
+
+	deleteIssuerMetrics("default/moved")
+
+	for _, gauge := range []struct {
+		name string
+		vec  *prometheus.GaugeVec
+		want int
+	}{
+		{name: "carbon intensity", vec: metrics.CipLiveCarbonIntensityMetric, want: 1},
+		{name: "renewable percentage", vec: metrics.CipLiveRenewablePercentageMetric, want: 1},
+		{name: "fossil free percentage", vec: metrics.CipLiveFossilFreePercentageMetric, want: 1},
+		{name: "power production", vec: metrics.CipLivePowerProductionMetric, want: 2},
+	} {
+		if got := testutil.CollectAndCount(gauge.vec); got != gauge.want {
+			t.Errorf("%s has %d series, want %d of the kept issuer", gauge.name, got, gauge.want)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rekuberate-io/carbon/pkg/common"
+	"github.com/rekuberate-io/carbon/pkg/providers"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	carbonv1beta1 "github.com/rekuberate-io/carbon/api/v1beta1"
+)
+
+const (
+	// providerCheckInterval is the interval healthy providers are checked in
+	providerCheckInterval = 15 * time.Minute
+	// providerRetryInterval is the interval failing providers are checked in
+	providerRetryInterval = time.Minute
+)
+
+var (
+	// issuerProviderRefFilters passes the issuer events that can change the
+	// dependent issuers of a provider
+	issuerProviderRefFilters = builder.WithPredicates(predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	})
+)
+
+// providerStatusReconciler checks the provider objects of one kind and reports
+// the outcome in their status.
+type providerStatusReconciler struct {
+	client.Client
+	Recorder record.EventRecorder
+
+	kind string
+}
+
+// reconcileProvider checks that a provider can be built from the provider
+// object, and that its credentials are accepted, then updates the status of
+// the object. status returns the status of the given object.
+func (r *providerStatusReconciler) reconcileProvider(
+	ctx context.Context,
+	req ctrl.Request,
+	before client.Object,
+	status func(o client.Object) *carbonv1alpha1.ProviderStatus,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithName("provider-controller")
+
+	if err := r.Get(ctx, req.NamespacedName, before); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			// the provider object was deleted, release its provider
+			providers.EvictProvider(r.kind, req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+
+		logger.V(dbglvl).Error(err, "unable to fetch provider", "providerKind", r.kind)
+		return ctrl.Result{}, err
+	}
+
+	after := before.DeepCopyObject().(client.Object)
+	beforeStatus := status(before)
+	afterStatus := status(after)
+
+	// initialize status conditions
+	if beforeStatus.Conditions == nil {
+		for _, condition := range carbonv1alpha1.GetProviderConditions() {
+			meta.SetStatusCondition(&afterStatus.Conditions, condition)
+		}
+	}
+
+	dependentIssuers, err := r.getDependentIssuers(ctx, before)
+	if err != nil {
+		logger.Error(err, "unable to list dependent issuers", "providerKind", r.kind, "provider", req.String())
+		return ctrl.Result{}, err
+	}
+	afterStatus.DependentIssuers = dependentIssuers
+
+	now := metav1.Now()
+	afterStatus.LastCheck = &now
+
+	credentials := carbonv1alpha1.ConditionCredentialsValid.DeepCopy()
+	ready := carbonv1alpha1.ConditionReady.DeepCopy()
+
+	err = r.checkProvider(ctx, req, credentials)
+	if err != nil {
+		afterStatus.ErrorCount++
+		afterStatus.ConsecutiveErrors++
+		afterStatus.LastError = err.Error()
+
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = carbonv1alpha1.ProviderNotReady
+		ready.Message = err.Error()
+
+		logger.Error(err, "provider check failed", "providerKind", r.kind, "provider", req.String())
+		r.Recorder.Event(before, corev1.EventTypeWarning, credentials.Reason, err.Error())
+	} else {
+		afterStatus.LastContact = &now
+		afterStatus.ConsecutiveErrors = 0
+		afterStatus.LastError = ""
+
+		ready.Status = metav1.ConditionTrue
+		ready.Reason = carbonv1alpha1.ProviderReady
+		ready.Message = fmt.Sprintf("Provider is serving %d issuer(s)", len(dependentIssuers))
+	}
+
+	credentials.ObservedGeneration = before.GetGeneration()
+	ready.ObservedGeneration = before.GetGeneration()
+	meta.SetStatusCondition(&afterStatus.Conditions, *credentials)
+	meta.SetStatusCondition(&afterStatus.Conditions, *ready)
+
+	if !reflect.DeepEqual(before, after) {
+		if err := r.Status().Update(ctx, after); err != nil {
+			logger.Error(err, "unable to update provider status", "providerKind", r.kind, "provider", req.String())
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err != nil {
+		return ctrl.Result{RequeueAfter: providerRetryInterval}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: providerCheckInterval}, nil
+}
+
+// checkProvider builds the provider of the provider object and checks its
+// credentials, reporting the outcome in the given condition.
+func (r *providerStatusReconciler) checkProvider(ctx context.Context, req ctrl.Request, condition *metav1.Condition) error {
+	providerRef := &corev1.ObjectReference{Kind: r.kind, Namespace: req.Namespace, Name: req.Name}
+
+	provider, err := providers.GetProvider(ctx, req, r.Client, providerRef)
+	if err == nil && provider == nil {
+		err = fmt.Errorf("unable to initialize provider '%s' (%s)", req.Name, r.kind)
+	}
+
+	if err == nil {
+		checker, ok := provider.(providers.CredentialsChecker)
+		if !ok {
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = carbonv1alpha1.CredentialsNotRequired
+			condition.Message = fmt.Sprintf("Provider '%s' (%s) does not require credentials", req.Name, r.kind)
+
+			return nil
+		}
+
+		err = checker.CheckCredentials(ctx)
+	}
+
+	switch {
+	case err == nil:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = carbonv1alpha1.CredentialsValid
+		condition.Message = fmt.Sprintf("Credentials of provider '%s' (%s) were accepted", req.Name, r.kind)
+	case errors.Is(err, common.ErrInvalidCredentials):
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = carbonv1alpha1.CredentialsInvalid
+		condition.Message = err.Error()
+	default:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = carbonv1alpha1.CredentialsCheckFailed
+		condition.Message = err.Error()
+	}
+
+	return err
+}
+
+// getDependentIssuers returns the sorted issuers that reference the provider
+// object, as namespace/name.
+func (r *providerStatusReconciler) getDependentIssuers(ctx context.Context, o client.Object) ([]string, error) {
+	issuers := &carbonv1beta1.CarbonIntensityIssuerList{}
+	err := r.List(
+		ctx,
+		issuers,
+		client.MatchingFields{providerRefIndexKey: providerRefIndexValue(r.kind, o.GetNamespace(), o.GetName())},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	dependentIssuers := make([]string, 0, len(issuers.Items))
+	for _, issuer := range issuers.Items {
+		dependentIssuers = append(dependentIssuers, client.ObjectKeyFromObject(&issuer).String())
+	}
+	sort.Strings(dependentIssuers)
+
+	if len(dependentIssuers) == 0 {
+		return nil, nil
+	}
+
+	return dependentIssuers, nil
+}
+
+// setupWithManager sets up a controller for the provider kind of o. Provider
+// objects are reconciled as well when their Secret changes, or when issuers
+// start or stop referencing them.
+func (r *providerStatusReconciler) setupWithManager(mgr ctrl.Manager, o client.Object, list client.ObjectList, reconciler reconcile.Reconciler) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(o, eventFilters).
+		Watches(
+			&source.Kind{Type: &carbonv1beta1.CarbonIntensityIssuer{}},
+			handler.EnqueueRequestsFromMapFunc(r.findProviderForIssuer),
+			issuerProviderRefFilters,
+		)
+
+	if list != nil {
+		b = b.Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(func(secret client.Object) []reconcile.Request {
+				return r.findProvidersForSecret(secret, list.DeepCopyObject().(client.ObjectList))
+			}),
+			builder.OnlyMetadata,
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		)
+	}
+
+	return b.Complete(reconciler)
+}
+
+// findProviderForIssuer enqueues the provider object an issuer references, if
+// it is of the kind of the reconciler.
+func (r *providerStatusReconciler) findProviderForIssuer(o client.Object) []reconcile.Request {
+	issuer, ok := o.(*carbonv1beta1.CarbonIntensityIssuer)
+	if !ok || issuer.Spec.ProviderRef == nil || !strings.EqualFold(issuer.Spec.ProviderRef.Kind, r.kind) {
+		return nil
+	}
+
+	namespace := issuer.Spec.ProviderRef.Namespace
+	if namespace == "" {
+		namespace = issuer.Namespace
+	}
+
+	return []reconcile.Request{
+		{NamespacedName: client.ObjectKey{Namespace: namespace, Name: issuer.Spec.ProviderRef.Name}},
+	}
+}
+
+// findProvidersForSecret enqueues the provider objects that reference a Secret.
+func (r *providerStatusReconciler) findProvidersForSecret(secret client.Object, list client.ObjectList) []reconcile.Request {
+	err := r.List(
+		context.Background(),
+		list,
+		client.MatchingFields{secretRefIndexKey: secretRefIndexValue(secret.GetNamespace(), "", secret.GetName())},
+	)
+	if err != nil {
+		return nil
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(items))
+	for _, item := range items {
+		if o, ok := item.(client.Object); ok {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(o)})
+		}
+	}
+
+	return requests
+}
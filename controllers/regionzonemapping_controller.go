@@ -0,0 +1,395 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"github.com/rekuberate-io/carbon/pkg/common"
+	"github.com/rekuberate-io/carbon/pkg/providers"
+	"github.com/rekuberate-io/carbon/pkg/regions"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"reflect"
+	"regexp"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	carbonv1beta1 "github.com/rekuberate-io/carbon/api/v1beta1"
+)
+
+const (
+	labelRegionZoneMapping = "core.rekuberate.io/region-zone-mapping"
+	labelRegion            = "core.rekuberate.io/region"
+
+	defaultRegionLabel = corev1.LabelTopologyRegion
+)
+
+var (
+	invalidNameCharacters = regexp.MustCompile(`[^a-z0-9-]+`)
+
+	nodeRegionFilters = builder.WithPredicates(predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			// only label changes can move a node to another region, the
+			// mappings decide which label holds the region
+			return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	})
+)
+
+// RegionZoneMappingReconciler reconciles a RegionZoneMapping object
+type RegionZoneMappingReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=core.rekuberate.io,resources=regionzonemappings,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core.rekuberate.io,resources=regionzonemappings/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=core.rekuberate.io,resources=regionzonemappings/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+
+// Reconcile creates an issuer for every distinct region of the nodes of the
+// cluster that maps to a zone, and deletes the issuers of regions that have
+// no nodes left.
+func (r *RegionZoneMappingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithName("region-controller")
+
+	before := &carbonv1alpha1.RegionZoneMapping{}
+	if err := r.Get(ctx, req.NamespacedName, before); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+
+		logger.V(dbglvl).Error(err, "unable to fetch region zone mapping")
+		return ctrl.Result{}, err
+	}
+
+	after := before.DeepCopy()
+
+	// initialize status conditions
+	if before.Status.Conditions == nil {
+		conditions := carbonv1alpha1.GetRegionZoneMappingConditions()
+		for _, condition := range conditions {
+			meta.SetStatusCondition(&after.Status.Conditions, condition)
+		}
+	}
+
+	clusterRegions, err := r.getClusterRegions(ctx, before)
+	if err != nil {
+		logger.Error(err, "unable to list nodes")
+		return ctrl.Result{}, err
+	}
+
+	// the built-in mappings depend on the zone naming of the provider
+	naming := common.AnyZone
+	if before.Spec.UseDefaultMappings == nil || *before.Spec.UseDefaultMappings {
+		providerRef := before.Spec.ProviderRef.DeepCopy()
+		if providerRef.Namespace == "" {
+			providerRef.Namespace = req.Namespace
+		}
+
+		provider, err := providers.GetProvider(ctx, req, r.Client, providerRef)
+		var capabilities *common.Capabilities
+		if err == nil {
+			capabilities, err = provider.GetCapabilities(ctx)
+		}
+		if err != nil {
+			condition := carbonv1alpha1.ConditionRegionsMapped.DeepCopy()
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = carbonv1alpha1.RegionsSyncError
+			condition.Message = err.Error()
+			meta.SetStatusCondition(&after.Status.Conditions, *condition)
+
+			logger.Error(err, "unable to get provider", "providerKind", providerRef.Kind)
+			r.updateStatus(ctx, before, after)
+
+			return ctrl.Result{}, err
+		}
+
+		naming = capabilities.ZoneNaming
+	}
+
+	mappedRegions := make([]carbonv1alpha1.MappedRegion, 0, len(clusterRegions))
+	unmappedRegions := make([]string, 0)
+	for _, region := range clusterRegions {
+		zone, ok := lookupZone(before, region, naming)
+		if !ok {
+			unmappedRegions = append(unmappedRegions, region)
+			continue
+		}
+
+		issuer, err := r.createOrUpdateIssuer(ctx, before, region, zone)
+		if err != nil {
+			condition := carbonv1alpha1.ConditionRegionsMapped.DeepCopy()
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = carbonv1alpha1.RegionsSyncError
+			condition.Message = err.Error()
+			meta.SetStatusCondition(&after.Status.Conditions, *condition)
+
+			logger.Error(err, "unable to create or update issuer", "region", region, "zone", zone)
+			r.updateStatus(ctx, before, after)
+
+			return ctrl.Result{}, err
+		}
+
+		mappedRegions = append(mappedRegions, carbonv1alpha1.MappedRegion{Region: region, Zone: zone, Issuer: issuer.Name})
+	}
+
+	if err := r.deleteStaleIssuers(ctx, before, mappedRegions); err != nil {
+		logger.Error(err, "unable to delete issuers of removed regions")
+		return ctrl.Result{}, err
+	}
+
+	condition := carbonv1alpha1.ConditionRegionsMapped.DeepCopy()
+	if len(unmappedRegions) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = carbonv1alpha1.RegionsUnmapped
+		condition.Message = fmt.Sprintf("No zone is known for the regions %s", strings.Join(unmappedRegions, ", "))
+
+		if !reflect.DeepEqual(before.Status.UnmappedRegions, unmappedRegions) {
+			r.Recorder.Event(before, corev1.EventTypeWarning, carbonv1alpha1.RegionsUnmapped, condition.Message)
+		}
+	} else {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = carbonv1alpha1.RegionsMapped
+		condition.Message = fmt.Sprintf("Mapped %d region(s) to zones", len(mappedRegions))
+	}
+	meta.SetStatusCondition(&after.Status.Conditions, *condition)
+
+	after.Status.Regions = mappedRegions
+	after.Status.UnmappedRegions = unmappedRegions
+	if len(unmappedRegions) == 0 {
+		after.Status.UnmappedRegions = nil
+	}
+
+	if !reflect.DeepEqual(before.Status, after.Status) {
+		after.Status.LastUpdate = &metav1.Time{Time: time.Now()}
+	}
+
+	return r.updateStatus(ctx, before, after)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RegionZoneMappingReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&carbonv1alpha1.RegionZoneMapping{}, eventFilters).
+		Owns(&carbonv1beta1.CarbonIntensityIssuer{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(
+			&source.Kind{Type: &corev1.Node{}},
+			handler.EnqueueRequestsFromMapFunc(r.findMappingsForNode),
+			nodeRegionFilters,
+		).
+		Complete(r)
+}
+
+// findMappingsForNode enqueues every mapping of the cluster, a node might be
+// relevant to any of them.
+func (r *RegionZoneMappingReconciler) findMappingsForNode(node client.Object) []reconcile.Request {
+	mappings := &carbonv1alpha1.RegionZoneMappingList{}
+	if err := r.List(context.Background(), mappings); err != nil {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(mappings.Items))
+	for _, mapping := range mappings.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&mapping)})
+	}
+
+	return requests
+}
+
+func (r *RegionZoneMappingReconciler) updateStatus(
+	ctx context.Context,
+	current *carbonv1alpha1.RegionZoneMapping,
+	desired *carbonv1alpha1.RegionZoneMapping,
+) (ctrl.Result, error) {
+	if !reflect.DeepEqual(current, desired) {
+		err := r.Status().Update(ctx, desired)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "unable to update region zone mapping status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// getClusterRegions returns the sorted, distinct regions of the nodes of the
+// cluster.
+func (r *RegionZoneMappingReconciler) getClusterRegions(ctx context.Context, mapping *carbonv1alpha1.RegionZoneMapping) ([]string, error) {
+	regionLabel := mapping.Spec.RegionLabel
+	if regionLabel == "" {
+		regionLabel = defaultRegionLabel
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes); err != nil {
+		return nil, err
+	}
+
+	distinct := map[string]struct{}{}
+	for _, node := range nodes.Items {
+		if region, ok := node.Labels[regionLabel]; ok && region != "" {
+			distinct[region] = struct{}{}
+		}
+	}
+
+	clusterRegions := make([]string, 0, len(distinct))
+	for region := range distinct {
+		clusterRegions = append(clusterRegions, region)
+	}
+	sort.Strings(clusterRegions)
+
+	return clusterRegions, nil
+}
+
+// createOrUpdateIssuer creates or updates the issuer of a region and makes the
+// mapping its controller, so that it is garbage collected together with it.
+func (r *RegionZoneMappingReconciler) createOrUpdateIssuer(
+	ctx context.Context,
+	mapping *carbonv1alpha1.RegionZoneMapping,
+	region string,
+	zone string,
+) (*carbonv1beta1.CarbonIntensityIssuer, error) {
+	issuer := &carbonv1beta1.CarbonIntensityIssuer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getRegionIssuerName(mapping.Name, region),
+			Namespace: mapping.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, issuer, func() error {
+		if !issuer.CreationTimestamp.IsZero() && !metav1.IsControlledBy(issuer, mapping) {
+			return fmt.Errorf("issuer '%s' already exists and is not managed by mapping '%s'", issuer.Name, mapping.Name)
+		}
+
+		if issuer.Labels == nil {
+			issuer.Labels = map[string]string{}
+		}
+		issuer.Labels[labelRegionZoneMapping] = mapping.Name
+		issuer.Labels[labelRegion] = region
+
+		issuer.Spec.Zone = zone
+		issuer.Spec.Location = nil
+		issuer.Spec.EmissionsType = mapping.Spec.EmissionsType
+		issuer.Spec.ProviderRef = mapping.Spec.ProviderRef.DeepCopy()
+		issuer.Spec.ForecastRefreshInterval = metav1.Duration{Duration: 12 * time.Hour}
+		if mapping.Spec.ForecastRefreshInterval != nil {
+			issuer.Spec.ForecastRefreshInterval = *mapping.Spec.ForecastRefreshInterval
+		}
+		issuer.Spec.LiveRefreshInterval = metav1.Duration{Duration: time.Hour}
+		if mapping.Spec.LiveRefreshInterval != nil {
+			issuer.Spec.LiveRefreshInterval = *mapping.Spec.LiveRefreshInterval
+		}
+
+		return controllerutil.SetControllerReference(mapping, issuer, r.Scheme)
+	})
+
+	return issuer, err
+}
+
+// deleteStaleIssuers deletes the issuers of the mapping whose region has no
+// nodes left, or no longer maps to a zone.
+func (r *RegionZoneMappingReconciler) deleteStaleIssuers(
+	ctx context.Context,
+	mapping *carbonv1alpha1.RegionZoneMapping,
+	mappedRegions []carbonv1alpha1.MappedRegion,
+) error {
+	issuers := &carbonv1beta1.CarbonIntensityIssuerList{}
+	err := r.List(
+		ctx,
+		issuers,
+		client.InNamespace(mapping.Namespace),
+		client.MatchingLabels{labelRegionZoneMapping: mapping.Name},
+	)
+	if err != nil {
+		return err
+	}
+
+	desired := map[string]struct{}{}
+	for _, mappedRegion := range mappedRegions {
+		desired[mappedRegion.Issuer] = struct{}{}
+	}
+
+	for i := range issuers.Items {
+		issuer := &issuers.Items[i]
+		if _, ok := desired[issuer.Name]; ok || !metav1.IsControlledBy(issuer, mapping) {
+			continue
+		}
+
+		if err := r.Delete(ctx, issuer); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lookupZone returns the zone of a region, preferring the mappings of the spec
+// over the built-in ones.
+func lookupZone(mapping *carbonv1alpha1.RegionZoneMapping, region string, naming common.ZoneNamingScheme) (string, bool) {
+	for _, m := range mapping.Spec.Mappings {
+		if m.Region == region {
+			return m.Zone, true
+		}
+	}
+
+	if mapping.Spec.UseDefaultMappings != nil && !*mapping.Spec.UseDefaultMappings {
+		return "", false
+	}
+
+	return regions.DefaultZone(region, naming)
+}
+
+// getRegionIssuerName returns the name of the issuer of a region. Regions that
+// are not valid names as they are get a short hash of the region appended, so
+// that regions like eu-west-1 and eu-west_1 do not share an issuer.
+func getRegionIssuerName(mappingName string, region string) string {
+	suffix := strings.Trim(invalidNameCharacters.ReplaceAllString(strings.ToLower(region), "-"), "-")
+	if suffix == region {
+		return fmt.Sprintf("%s-%s", mappingName, suffix)
+	}
+
+	hash := sha256.Sum256([]byte(region))
+	if suffix == "" {
+		return fmt.Sprintf("%s-%x", mappingName, hash[:4])
+	}
+
+	return fmt.Sprintf("%s-%s-%x", mappingName, suffix, hash[:4])
+}
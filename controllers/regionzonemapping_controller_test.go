@@ -0,0 +1,157 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rekuberate-io/carbon/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	carbonv1beta1 "github.com/rekuberate-io/carbon/api/v1beta1"
+)
+
+func TestGetRegionIssuerName(t *testing.T) {
+	tests := []struct {
+		region string
+		want   string
+	}{
+		{region: "eu-west-1", want: "mapping-eu-west-1"},
+		{region: "westeurope", want: "mapping-westeurope"},
+		{region: "eu-west_1"},
+		{region: "EU-West-1"},
+		{region: "___"},
+	}
+
+	names := map[string]string{}
+	for _, tt := range tests {
+		name := getRegionIssuerName("mapping", tt.region)
+		if tt.want != "" && name != tt.want {
+			t.Errorf("issuer of %s = %s, want %s", tt.region, name, tt.want)
+		}
+		if errs := validation.NameIsDNSSubdomain(name, false); len(errs) > 0 {
+			t.Errorf("issuer of %s = %s, not a valid name: %v", tt.region, name, errs)
+		}
+		if other, ok := names[name]; ok {
+			t.Errorf("regions %s and %s share the issuer %s", other, tt.region, name)
+		}
+		names[name] = tt.region
+	}
+}
+
+func TestLookupZone(t *testing.T) {
+	useDefaultMappings := false
+	tests := []struct {
+		name     string
+		mappings []carbonv1alpha1.RegionMapping
+		defaults *bool
+		region   string
+		zone     string
+		ok       bool
+	}{
+		{name: "default mapping", region: "eu-west-1", zone: "IE", ok: true},
+		{name: "spec mapping wins", mappings: []carbonv1alpha1.RegionMapping{{Region: "eu-west-1", Zone: "GB"}}, region: "eu-west-1", zone: "GB", ok: true},
+		{name: "default mappings disabled", defaults: &useDefaultMappings, region: "eu-west-1"},
+		{name: "unknown region", region: "on-prem"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping := &carbonv1alpha1.RegionZoneMapping{
+				Spec: carbonv1alpha1.RegionZoneMappingSpec{Mappings: tt.mappings, UseDefaultMappings: tt.defaults},
+			}
+
+			zone, ok := lookupZone(mapping, tt.region, common.AnyZone)
+			if zone != tt.zone || ok != tt.ok {
+				t.Errorf("lookupZone(%s) = %q, %t, want %q, %t", tt.region, zone, ok, tt.zone, tt.ok)
+			}
+		})
+	}
+}
+
+func TestReconcileCollidingRegions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		carbonv1alpha1.AddToScheme,
+		carbonv1beta1.AddToScheme,
+	} {
+		if err := addToScheme(scheme); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	useDefaultMappings := false
+	mapping := &carbonv1alpha1.RegionZoneMapping{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mapping"},
+		Spec: carbonv1alpha1.RegionZoneMappingSpec{
+			UseDefaultMappings: &useDefaultMappings,
+			Mappings: []carbonv1alpha1.RegionMapping{
+				{Region: "eu-west-1", Zone: "IE"},
+				{Region: "eu-west_1", Zone: "GB"},
+			},
+			ProviderRef: &corev1.ObjectReference{Kind: "Simulator", Name: "simulator-sample"},
+		},
+	}
+	node := func(name string, region string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{defaultRegionLabel: region}}}
+	}
+
+	r := &RegionZoneMappingReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			mapping,
+			node("node-a", "eu-west-1"),
+			node("node-b", "eu-west_1"),
+		).Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	ctx := context.Background()
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(mapping)}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Get(ctx, client.ObjectKeyFromObject(mapping), mapping); err != nil {
+		t.Fatal(err)
+	}
+	if len(mapping.Status.Regions) != 2 || mapping.Status.Regions[0].Issuer == mapping.Status.Regions[1].Issuer {
+		t.Fatalf("regions = %v, want an issuer of each region", mapping.Status.Regions)
+	}
+
+	for _, mappedRegion := range mapping.Status.Regions {
+		issuer := &carbonv1beta1.CarbonIntensityIssuer{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: mappedRegion.Issuer}, issuer); err != nil {
+			t.Fatal(err)
+		}
+
+		if issuer.Spec.Zone != mappedRegion.Zone || issuer.Labels[labelRegion] != mappedRegion.Region {
+			t.Errorf("issuer %s of zone %s and region %s, want %s and %s",
+				issuer.Name, issuer.Spec.Zone, issuer.Labels[labelRegion], mappedRegion.Zone, mappedRegion.Region)
+		}
+	}
+}
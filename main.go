@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"go.uber.org/zap/zapcore"
 	"os"
@@ -25,15 +26,19 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	corev1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	corev1beta1 "github.com/rekuberate-io/carbon/api/v1beta1"
 	"github.com/rekuberate-io/carbon/controllers"
+	"github.com/rekuberate-io/carbon/pkg/webhooks"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -46,6 +51,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(corev1alpha1.AddToScheme(scheme))
+	utilruntime.Must(corev1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -74,6 +80,9 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "d0a92195.rekuberate.io",
+		// Secrets and ConfigMaps are only watched as metadata, so they are read
+		// from the API server instead of keeping every one of them in the cache.
+		ClientDisableCacheFor: []client.Object{&corev1.Secret{}, &corev1.ConfigMap{}},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -91,6 +100,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = controllers.SetupIndexes(context.Background(), mgr); err != nil {
+		setupLog.Error(err, "unable to set up field indexes")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.CarbonIntensityIssuerReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
@@ -99,6 +113,147 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "CarbonIntensityIssuer")
 		os.Exit(1)
 	}
+	if err = (&controllers.RegionZoneMappingReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("region-zone-mapping-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RegionZoneMapping")
+		os.Exit(1)
+	}
+	if err = (&controllers.WattTimeReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("watttime-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "WattTime")
+		os.Exit(1)
+	}
+	if err = (&controllers.ElectricityMapsReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("electricitymaps-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElectricityMaps")
+		os.Exit(1)
+	}
+	if err = (&controllers.SimulatorReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("simulator-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Simulator")
+		os.Exit(1)
+	}
+	if err = (&controllers.NationalGridReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("nationalgrid-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NationalGrid")
+		os.Exit(1)
+	}
+	if err = (&controllers.GenericHTTPReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("generichttp-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GenericHTTP")
+		os.Exit(1)
+	}
+	if err = (&controllers.StaticTableReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("statictable-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "StaticTable")
+		os.Exit(1)
+	}
+	if err = (&controllers.PrometheusQueryReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("prometheusquery-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PrometheusQuery")
+		os.Exit(1)
+	}
+	if err = (&controllers.CompositeProviderReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("compositeprovider-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CompositeProvider")
+		os.Exit(1)
+	}
+	if err = (&controllers.ENTSOEReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("entsoe-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ENTSOE")
+		os.Exit(1)
+	}
+	if err = (&controllers.EIAReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("eia-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EIA")
+		os.Exit(1)
+	}
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		validator := &webhooks.CarbonIntensityIssuerValidator{Client: mgr.GetClient()}
+		if err = (&corev1beta1.CarbonIntensityIssuer{}).SetupWebhookWithManager(mgr, validator); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CarbonIntensityIssuer")
+			os.Exit(1)
+		}
+		if err = (&corev1alpha1.WattTime{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "WattTime")
+			os.Exit(1)
+		}
+		if err = (&corev1alpha1.ElectricityMaps{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ElectricityMaps")
+			os.Exit(1)
+		}
+		if err = (&corev1alpha1.Simulator{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Simulator")
+			os.Exit(1)
+		}
+		if err = (&corev1alpha1.NationalGrid{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "NationalGrid")
+			os.Exit(1)
+		}
+		if err = (&corev1alpha1.GenericHTTP{}).SetupWebhookWithManager(mgr, &webhooks.GenericHTTPValidator{}); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "GenericHTTP")
+			os.Exit(1)
+		}
+		if err = (&corev1alpha1.StaticTable{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "StaticTable")
+			os.Exit(1)
+		}
+		if err = (&corev1alpha1.PrometheusQuery{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "PrometheusQuery")
+			os.Exit(1)
+		}
+		compositeValidator := &webhooks.CompositeProviderValidator{Client: mgr.GetClient()}
+		if err = (&corev1alpha1.CompositeProvider{}).SetupWebhookWithManager(mgr, compositeValidator); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CompositeProvider")
+			os.Exit(1)
+		}
+		if err = (&corev1alpha1.ENTSOE{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ENTSOE")
+			os.Exit(1)
+		}
+		if err = (&corev1alpha1.EIA{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "EIA")
+			os.Exit(1)
+		}
+		mappingValidator := &webhooks.RegionZoneMappingValidator{Client: mgr.GetClient()}
+		if err = (&corev1alpha1.RegionZoneMapping{}).SetupWebhookWithManager(mgr, mappingValidator); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RegionZoneMapping")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
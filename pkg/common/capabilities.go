@@ -0,0 +1,49 @@
+package common
+
+import (
+	"slices"
+	"time"
+)
+
+type ZoneNamingScheme string
+
+const (
+	// BalancingAuthority zones are WattTime balancing authority abbreviations,
+	// e.g. CAISO_NORTH; the EIA provider accepts its own codes as well, e.g. CISO
+	BalancingAuthority ZoneNamingScheme = "balancing_authority"
+	// ElectricityMapsZone zones are ElectricityMaps zone keys, e.g. DE or US-CAL-CISO
+	ElectricityMapsZone ZoneNamingScheme = "electricitymaps_zone"
+	// NationalGridZone zones are GB, a region ID of the Carbon Intensity API
+	// or the outward code of a UK postcode
+	NationalGridZone ZoneNamingScheme = "nationalgrid_zone"
+	// AnyZone means that the provider accepts any zone name
+	AnyZone ZoneNamingScheme = "any"
+)
+
+// RateLimit is the number of requests a provider accepts within a period.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Capabilities describes what a configured provider is able to deliver, so
+// that issuers can be validated before any data is fetched.
+type Capabilities struct {
+	// Forecast is true when the provider is able to deliver forecasts
+	Forecast bool
+	// ForecastHorizon is the time span a forecast usually covers
+	ForecastHorizon time.Duration
+	// Resolution is the native interval of the provider's data points
+	Resolution     time.Duration
+	EmissionsTypes []EmissionsType
+	ZoneNaming     ZoneNamingScheme
+	// RateLimit is the published rate limit of the provider, nil if there is
+	// none or it is unknown
+	RateLimit *RateLimit
+}
+
+// SupportsEmissionsType returns true when the provider reports the given
+// emissions type.
+func (c *Capabilities) SupportsEmissionsType(emissionsType EmissionsType) bool {
+	return slices.Contains(c.EmissionsTypes, emissionsType)
+}
@@ -0,0 +1,15 @@
+package common
+
+type EmissionsType string
+
+const (
+	Average  EmissionsType = "average"
+	Marginal EmissionsType = "marginal"
+)
+
+type EmissionFactorType string
+
+const (
+	Lifecycle EmissionFactorType = "lifecycle"
+	Direct    EmissionFactorType = "direct"
+)
@@ -0,0 +1,9 @@
+package common
+
+import "errors"
+
+var (
+	// ErrInvalidCredentials is wrapped by the errors of providers that reject
+	// the credentials they were configured with
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
@@ -0,0 +1,102 @@
+package common
+
+import (
+	"slices"
+	"time"
+)
+
+type Unit string
+
+const (
+	GramsPerKilowattHour Unit = "gCO2eq/kWh"
+)
+
+type SignalType string
+
+const (
+	CarbonIntensity       SignalType = "carbon_intensity"
+	MarginalOperatingRate SignalType = "co2_moer"
+)
+
+const (
+	defaultForecastResolution = time.Hour
+)
+
+// ForecastPoint is a single value of a Forecast, valid from PointTime for the
+// duration of the Forecast's Resolution.
+type ForecastPoint struct {
+	PointTime time.Time
+	Value     float64
+}
+
+// Forecast is an ordered carbon intensity forecast of a zone, as it was
+// generated by a provider.
+type Forecast struct {
+	Zone          string
+	GeneratedAt   time.Time
+	Horizon       time.Duration
+	Resolution    time.Duration
+	Unit          Unit
+	SignalType    SignalType
+	EmissionsType EmissionsType
+	Points        []ForecastPoint
+}
+
+// NewForecast sorts the given points by time and derives the resolution and
+// horizon of the forecast from them. If the points are not enough to derive a
+// resolution, the given fallback resolution is used instead.
+func NewForecast(
+	zone string,
+	generatedAt time.Time,
+	unit Unit,
+	signalType SignalType,
+	emissionsType EmissionsType,
+	resolution time.Duration,
+	points []ForecastPoint,
+) *Forecast {
+	slices.SortFunc(points, func(a, b ForecastPoint) int {
+		return a.PointTime.Compare(b.PointTime)
+	})
+
+	if len(points) > 1 {
+		resolution = points[1].PointTime.Sub(points[0].PointTime)
+	}
+
+	if resolution <= 0 {
+		resolution = defaultForecastResolution
+	}
+
+	var horizon time.Duration
+	if len(points) > 0 {
+		horizon = points[len(points)-1].PointTime.Add(resolution).Sub(points[0].PointTime)
+	}
+
+	return &Forecast{
+		Zone:          zone,
+		GeneratedAt:   generatedAt,
+		Horizon:       horizon,
+		Resolution:    resolution,
+		Unit:          unit,
+		SignalType:    signalType,
+		EmissionsType: emissionsType,
+		Points:        points,
+	}
+}
+
+// Start returns the time of the first point of the forecast.
+func (f *Forecast) Start() time.Time {
+	if len(f.Points) == 0 {
+		return time.Time{}
+	}
+
+	return f.Points[0].PointTime
+}
+
+// End returns the time until the last point of the forecast is valid.
+func (f *Forecast) End() time.Time {
+	if len(f.Points) == 0 {
+		return time.Time{}
+	}
+
+	return f.Points[len(f.Points)-1].PointTime.Add(f.Resolution)
+}
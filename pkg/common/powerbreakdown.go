@@ -0,0 +1,20 @@
+package common
+
+import "time"
+
+// PowerBreakdown is the origin of the electricity of a zone at a point in time
+type PowerBreakdown struct {
+	Zone      string
+	PointTime time.Time
+	UpdatedAt time.Time
+
+	// RenewablePercentage and FossilFreePercentage are nil if the provider
+	// did not report them
+	RenewablePercentage  *float64
+	FossilFreePercentage *float64
+
+	// Production is the power produced per source, in MW
+	Production map[string]float64
+	// ProductionTotal is the total power produced, in MW
+	ProductionTotal float64
+}
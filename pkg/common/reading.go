@@ -0,0 +1,30 @@
+package common
+
+import (
+	"time"
+)
+
+// Reading is the latest carbon intensity of a zone, as it was reported by a
+// provider, along with the metadata the provider attached to it.
+type Reading struct {
+	Zone          string
+	Value         float64
+	Unit          Unit
+	SignalType    SignalType
+	EmissionsType EmissionsType
+	// PointTime is the time the value was observed (or estimated) for.
+	PointTime time.Time
+	// UpdatedAt is the time the provider last updated the value, if reported.
+	UpdatedAt          time.Time
+	IsEstimated        bool
+	EstimationMethod   string
+	EmissionFactorType EmissionFactorType
+	// Percent is the relative position of the value within the range of the
+	// last weeks (0-100), if reported.
+	Percent *float64
+	// Frequency is the update interval of the value, if reported.
+	Frequency time.Duration
+	// ServedBy are the provider objects that delivered the value, if the
+	// provider combines several of them.
+	ServedBy []string
+}
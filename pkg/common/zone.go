@@ -0,0 +1,8 @@
+package common
+
+// Zone is a grid zone a provider delivers carbon intensities for.
+type Zone struct {
+	// Name is the identifier of the zone, as it is used in CarbonIntensityIssuer
+	Name        string
+	DisplayName string
+}
@@ -0,0 +1,74 @@
+package emissionfactors
+
+import (
+	"github.com/rekuberate-io/carbon/pkg/common"
+)
+
+// Fuel is a fuel of power generation, which providers map their fuel or
+// production types to.
+type Fuel string
+
+const (
+	Coal           Fuel = "coal"
+	Lignite        Fuel = "lignite"
+	Gas            Fuel = "gas"
+	Oil            Fuel = "oil"
+	Peat           Fuel = "peat"
+	Nuclear        Fuel = "nuclear"
+	Hydro          Fuel = "hydro"
+	Wind           Fuel = "wind"
+	Solar          Fuel = "solar"
+	Geothermal     Fuel = "geothermal"
+	Biomass        Fuel = "biomass"
+	Marine         Fuel = "marine"
+	Waste          Fuel = "waste"
+	OtherRenewable Fuel = "otherRenewable"
+	Storage        Fuel = "storage"
+	Other          Fuel = "other"
+)
+
+// defaults are the emission factors of the IPCC AR5 (2014), Annex III, in
+// gCO2eq/kWh. Lifecycle factors are the medians of the technologies of a fuel;
+// lignite and peat use the factor of coal, other fuels a conservative one.
+// Direct factors are the medians of the combustion emissions, biogenic CO2
+// counts as neutral, and fuels the AR5 lists no direct emissions for keep
+// their lifecycle factor. Storage counts as emission-free, as it releases
+// power that was already accounted for when it was generated.
+var defaults = map[common.EmissionFactorType]map[Fuel]float64{
+	common.Lifecycle: {
+		Coal:           820,
+		Lignite:        820,
+		Gas:            490,
+		Oil:            650,
+		Peat:           820,
+		Nuclear:        12,
+		Hydro:          24,
+		Wind:           11,
+		Solar:          45,
+		Geothermal:     38,
+		Biomass:        230,
+		Marine:         17,
+		Waste:          580,
+		OtherRenewable: 38,
+		Storage:        0,
+		Other:          700,
+	},
+	common.Direct: {
+		Coal:           760,
+		Lignite:        760,
+		Gas:            370,
+		Oil:            650,
+		Peat:           760,
+		Nuclear:        0,
+		Hydro:          0,
+		Wind:           0,
+		Solar:          0,
+		Geothermal:     0,
+		Biomass:        0,
+		Marine:         0,
+		Waste:          580,
+		OtherRenewable: 0,
+		Storage:        0,
+		Other:          700,
+	},
+}
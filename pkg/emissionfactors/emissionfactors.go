@@ -0,0 +1,108 @@
+// Package emissionfactors provides the emission factors that carbon
+// intensities are computed from the generation per fuel with.
+package emissionfactors
+
+import (
+	"fmt"
+	"strconv"
+
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	"github.com/rekuberate-io/carbon/pkg/common"
+)
+
+// Set is the emission factor per fuel of a type, optionally overridden within
+// single zones.
+type Set struct {
+	factorType common.EmissionFactorType
+	factors    map[Fuel]float64
+	zones      map[string]map[Fuel]float64
+}
+
+// Default returns the set of the built-in lifecycle factors.
+func Default() *Set {
+	return &Set{factorType: common.Lifecycle, factors: defaults[common.Lifecycle]}
+}
+
+// NewSet returns the set of an EmissionFactorSet object, the factors of its
+// spec merged over the built-in ones of its type unless they are disabled.
+func NewSet(o *carbonv1alpha1.EmissionFactorSet) (*Set, error) {
+	s := &Set{
+		factorType: common.Lifecycle,
+		factors:    map[Fuel]float64{},
+		zones:      map[string]map[Fuel]float64{},
+	}
+
+	if o.Spec.Type != "" {
+		s.factorType = common.EmissionFactorType(o.Spec.Type)
+	}
+
+	builtIn, ok := defaults[s.factorType]
+	if !ok {
+		return nil, fmt.Errorf("not supported emission factor type '%s'", s.factorType)
+	}
+
+	if o.Spec.UseDefaults == nil || *o.Spec.UseDefaults {
+		for fuel, factor := range builtIn {
+			s.factors[fuel] = factor
+		}
+	}
+
+	if err := parseFactors(o.Spec.Factors, s.factors); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.factors[Other]; !ok {
+		return nil, fmt.Errorf("emission factor of '%s' is missing", Other)
+	}
+
+	for _, zone := range o.Spec.Zones {
+		factors := map[Fuel]float64{}
+		if err := parseFactors(zone.Factors, factors); err != nil {
+			return nil, fmt.Errorf("zone '%s': %w", zone.Zone, err)
+		}
+
+		s.zones[zone.Zone] = factors
+	}
+
+	return s, nil
+}
+
+// Type returns the type of the factors, lifecycle or direct.
+func (s *Set) Type() common.EmissionFactorType {
+	return s.factorType
+}
+
+// Factor returns the emission factor of a fuel within a zone, in gCO2eq/kWh.
+// Factors of the zone take precedence, fuels without a factor count as other.
+func (s *Set) Factor(zone string, fuel Fuel) float64 {
+	if factor, ok := s.zones[zone][fuel]; ok {
+		return factor
+	}
+
+	if factor, ok := s.factors[fuel]; ok {
+		return factor
+	}
+
+	if factor, ok := s.zones[zone][Other]; ok {
+		return factor
+	}
+
+	return s.factors[Other]
+}
+
+func parseFactors(values map[string]carbonv1alpha1.EmissionFactor, factors map[Fuel]float64) error {
+	for fuel, value := range values {
+		if _, ok := defaults[common.Lifecycle][Fuel(fuel)]; !ok {
+			return fmt.Errorf("unknown fuel '%s'", fuel)
+		}
+
+		factor, err := strconv.ParseFloat(string(value), 64)
+		if err != nil {
+			return fmt.Errorf("emission factor of '%s': %w", fuel, err)
+		}
+
+		factors[Fuel(fuel)] = factor
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package emissionfactors
+
+import (
+	"testing"
+
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	"github.com/rekuberate-io/carbon/pkg/common"
+)
+
+func TestDefaults(t *testing.T) {
+	for factorType, factors := range defaults {
+		for fuel := range defaults[common.Lifecycle] {
+			if _, ok := factors[fuel]; !ok {
+				t.Errorf("%s factor of %s is missing", factorType, fuel)
+			}
+		}
+	}
+
+	set := Default()
+	if set.Type() != common.Lifecycle || set.Factor("DE", Coal) != 820 {
+		t.Errorf("default = %s %v for coal, want lifecycle 820", set.Type(), set.Factor("DE", Coal))
+	}
+}
+
+func TestNewSet(t *testing.T) {
+	useDefaults := false
+	tests := []struct {
+		name    string
+		spec    carbonv1alpha1.EmissionFactorSetSpec
+		zone    string
+		fuel    Fuel
+		want    float64
+		wantErr bool
+	}{
+		{
+			name: "direct defaults",
+			spec: carbonv1alpha1.EmissionFactorSetSpec{Type: carbonv1alpha1.Direct},
+			fuel: Gas,
+			want: 370,
+		},
+		{
+			name: "override",
+			spec: carbonv1alpha1.EmissionFactorSetSpec{Factors: map[string]carbonv1alpha1.EmissionFactor{"gas": "450"}},
+			fuel: Gas,
+			want: 450,
+		},
+		{
+			name: "zone override",
+			spec: carbonv1alpha1.EmissionFactorSetSpec{
+				Factors: map[string]carbonv1alpha1.EmissionFactor{"gas": "450"},
+				Zones:   []carbonv1alpha1.ZoneEmissionFactors{{Zone: "NL", Factors: map[string]carbonv1alpha1.EmissionFactor{"gas": "420"}}},
+			},
+			zone: "NL",
+			fuel: Gas,
+			want: 420,
+		},
+		{
+			name: "without defaults",
+			spec: carbonv1alpha1.EmissionFactorSetSpec{
+				UseDefaults: &useDefaults,
+				Factors:     map[string]carbonv1alpha1.EmissionFactor{"coal": "900", "other": "500"},
+			},
+			fuel: Nuclear,
+			want: 500,
+		},
+		{
+			name:    "without defaults and other",
+			spec:    carbonv1alpha1.EmissionFactorSetSpec{UseDefaults: &useDefaults, Factors: map[string]carbonv1alpha1.EmissionFactor{"coal": "900"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown fuel",
+			spec:    carbonv1alpha1.EmissionFactorSetSpec{Factors: map[string]carbonv1alpha1.EmissionFactor{"hardCoal": "900"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		set, err := NewSet(&carbonv1alpha1.EmissionFactorSet{Spec: tt.spec})
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("%s: err = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+
+		if err == nil && set.Factor(tt.zone, tt.fuel) != tt.want {
+			t.Errorf("%s: factor of %s = %v, want %v", tt.name, tt.fuel, set.Factor(tt.zone, tt.fuel), tt.want)
+		}
+	}
+}
@@ -0,0 +1,535 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	"github.com/rekuberate-io/carbon/pkg/common"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrProviderCycle = errors.New("composite provider references itself")
+)
+
+// compositeMember is a member of a CompositeProvider, along with the error that
+// occurred while building its provider, if any.
+type compositeMember struct {
+	name     string
+	zone     string
+	weight   float64
+	provider Provider
+	err      error
+}
+
+// CompositeProvider combines the providers of other provider objects. It fails
+// over between them in order, or serves an ensemble or weighted blend of the
+// members that deliver.
+type CompositeProvider struct {
+	mode        carbonv1alpha1.CompositeMode
+	aggregation carbonv1alpha1.CompositeAggregation
+	members     []compositeMember
+}
+
+// memberResult is a value a member delivered.
+type memberResult[T any] struct {
+	member *compositeMember
+	value  T
+}
+
+// newCompositeProvider returns a provider for the CompositeProvider object,
+// with get building the provider of each member. Members that can not be built
+// are kept along with their error, so that the others still serve.
+func newCompositeProvider(
+	o *carbonv1alpha1.CompositeProvider,
+	get func(ref carbonv1alpha1.CompositeProviderMember) (Provider, error),
+) *CompositeProvider {
+	p := &CompositeProvider{
+		mode:        carbonv1alpha1.Failover,
+		aggregation: carbonv1alpha1.Median,
+		members:     make([]compositeMember, 0, len(o.Spec.Members)),
+	}
+
+	if o.Spec.Mode != "" {
+		p.mode = o.Spec.Mode
+	}
+
+	if o.Spec.Aggregation != "" {
+		p.aggregation = o.Spec.Aggregation
+	}
+
+	for _, m := range o.Spec.Members {
+		member := compositeMember{zone: m.Zone, weight: 1}
+		if m.Weight > 0 && p.mode == carbonv1alpha1.WeightedBlend {
+			member.weight = float64(m.Weight)
+		}
+
+		if m.ProviderRef == nil {
+			member.name = "<missing>"
+			member.err = fmt.Errorf("provider reference is missing")
+			p.members = append(p.members, member)
+			continue
+		}
+
+		namespace := m.ProviderRef.Namespace
+		if namespace == "" {
+			namespace = o.Namespace
+		}
+
+		member.name = fmt.Sprintf("%s/%s/%s", m.ProviderRef.Kind, namespace, m.ProviderRef.Name)
+		if m.Zone != "" {
+			member.name = fmt.Sprintf("%s (%s)", member.name, m.Zone)
+		}
+
+		member.provider, member.err = get(m)
+		if member.provider == nil && member.err == nil {
+			member.err = fmt.Errorf("unable to initialize provider")
+		}
+
+		p.members = append(p.members, member)
+	}
+
+	return p
+}
+
+// GetCapabilities merges the capabilities of the members. The composite
+// forecasts if any member does, with the coarsest resolution of all members,
+// and reports the emissions types all members have in common.
+func (p *CompositeProvider) GetCapabilities(ctx context.Context) (*common.Capabilities, error) {
+	var merged *common.Capabilities
+	var errs []error
+
+	for i := range p.members {
+		member := &p.members[i]
+		if member.err != nil {
+			errs = append(errs, member.wrap(member.err))
+			continue
+		}
+
+		capabilities, err := member.provider.GetCapabilities(ctx)
+		if err != nil {
+			errs = append(errs, member.wrap(err))
+			continue
+		}
+
+		zoneNaming := capabilities.ZoneNaming
+		if member.zone != "" {
+			zoneNaming = common.AnyZone
+		}
+
+		if merged == nil {
+			merged = &common.Capabilities{
+				Forecast:        capabilities.Forecast,
+				ForecastHorizon: capabilities.ForecastHorizon,
+				Resolution:      capabilities.Resolution,
+				EmissionsTypes:  slices.Clone(capabilities.EmissionsTypes),
+				ZoneNaming:      zoneNaming,
+			}
+			continue
+		}
+
+		if capabilities.Forecast {
+			switch {
+			case !merged.Forecast:
+				merged.ForecastHorizon = capabilities.ForecastHorizon
+			case p.mode == carbonv1alpha1.Failover:
+				merged.ForecastHorizon = max(merged.ForecastHorizon, capabilities.ForecastHorizon)
+			default:
+				merged.ForecastHorizon = min(merged.ForecastHorizon, capabilities.ForecastHorizon)
+			}
+			merged.Forecast = true
+		}
+
+		merged.Resolution = max(merged.Resolution, capabilities.Resolution)
+		merged.EmissionsTypes = slices.DeleteFunc(merged.EmissionsTypes, func(e common.EmissionsType) bool {
+			return !capabilities.SupportsEmissionsType(e)
+		})
+
+		if merged.ZoneNaming != zoneNaming {
+			merged.ZoneNaming = common.AnyZone
+		}
+	}
+
+	if merged == nil {
+		return nil, p.noMember(errs)
+	}
+
+	if len(merged.EmissionsTypes) == 0 {
+		return nil, errors.New("members have no emissions type in common")
+	}
+
+	return merged, nil
+}
+
+// GetZones returns the zones of the members that are queried for the zone of
+// the issuer.
+func (p *CompositeProvider) GetZones(ctx context.Context) ([]common.Zone, error) {
+	zones := make([]common.Zone, 0)
+	var errs []error
+	listed := false
+
+	for i := range p.members {
+		member := &p.members[i]
+		if member.zone != "" {
+			continue
+		}
+
+		if member.err != nil {
+			errs = append(errs, member.wrap(member.err))
+			continue
+		}
+
+		memberZones, err := member.provider.GetZones(ctx)
+		if err != nil {
+			errs = append(errs, member.wrap(err))
+			continue
+		}
+
+		listed = true
+		for _, zone := range memberZones {
+			if !slices.ContainsFunc(zones, func(z common.Zone) bool { return z.Name == zone.Name }) {
+				zones = append(zones, zone)
+			}
+		}
+	}
+
+	if !listed && len(errs) > 0 {
+		return nil, p.noMember(errs)
+	}
+
+	return zones, nil
+}
+
+// IsValidZone reports whether any member that is queried for the zone of the
+// issuer accepts it. Any zone is valid if every member has a zone of its own,
+// the zone of the issuer then merely names the site.
+func (p *CompositeProvider) IsValidZone(ctx context.Context, zone string) (bool, error) {
+	var errs []error
+	checked := false
+
+	for i := range p.members {
+		member := &p.members[i]
+		if member.zone != "" {
+			continue
+		}
+
+		if member.err != nil {
+			errs = append(errs, member.wrap(member.err))
+			continue
+		}
+
+		err := ValidateZone(ctx, member.provider, zone)
+		switch {
+		case err == nil:
+			return true, nil
+		case errors.Is(err, ErrUnknownZone):
+			checked = true
+		default:
+			errs = append(errs, member.wrap(err))
+		}
+	}
+
+	if !checked && len(errs) > 0 {
+		return false, p.noMember(errs)
+	}
+
+	return !checked, nil
+}
+
+// CheckCredentials checks the credentials of every member. It fails only if no
+// member passes, as the composite still serves from the others.
+func (p *CompositeProvider) CheckCredentials(ctx context.Context) error {
+	var errs []error
+
+	for i := range p.members {
+		member := &p.members[i]
+		if member.err != nil {
+			errs = append(errs, member.wrap(member.err))
+			continue
+		}
+
+		if checker, ok := member.provider.(CredentialsChecker); ok {
+			if err := checker.CheckCredentials(ctx); err != nil {
+				errs = append(errs, member.wrap(err))
+				continue
+			}
+		}
+
+		return nil
+	}
+
+	return p.noMember(errs)
+}
+
+// GetCurrent returns the reading of the first member that delivers one, or
+// combines the readings of all members that deliver one.
+func (p *CompositeProvider) GetCurrent(ctx context.Context, zone string) (*common.Reading, error) {
+	results, errs := collect(p, func(member *compositeMember) (*common.Reading, error) {
+		return member.provider.GetCurrent(ctx, member.zoneFor(zone))
+	})
+
+	if len(results) == 0 {
+		return nil, p.noMember(errs)
+	}
+
+	first := results[0].value
+	reading := *first
+	reading.Zone = zone
+	reading.ServedBy = results[0].member.servedBy(first)
+
+	if p.mode == carbonv1alpha1.Failover {
+		return &reading, nil
+	}
+
+	// the combined value is as old as the oldest value it is made of
+	values := make([]weightedValue, 0, len(results))
+	reading.ServedBy = nil
+	reading.Percent = nil
+	for _, result := range results {
+		r := result.value
+		values = append(values, weightedValue{value: r.Value, weight: result.member.weight})
+		reading.ServedBy = append(reading.ServedBy, result.member.servedBy(r)...)
+
+		if r.PointTime.Before(reading.PointTime) {
+			reading.PointTime = r.PointTime
+		}
+
+		if r.UpdatedAt.Before(reading.UpdatedAt) {
+			reading.UpdatedAt = r.UpdatedAt
+		}
+
+		if r.IsEstimated && !reading.IsEstimated {
+			reading.IsEstimated = true
+			reading.EstimationMethod = r.EstimationMethod
+		}
+
+		if r.EmissionFactorType != reading.EmissionFactorType {
+			reading.EmissionFactorType = ""
+		}
+
+		reading.Frequency = max(reading.Frequency, r.Frequency)
+	}
+
+	reading.Value = p.combine(values)
+
+	return &reading, nil
+}
+
+// GetForecast returns the forecast of the first member that delivers one, or
+// combines the forecasts of all members that deliver one at the points of the
+// first of them.
+func (p *CompositeProvider) GetForecast(ctx context.Context, zone string) (*common.Forecast, error) {
+	results, errs := collect(p, func(member *compositeMember) (*common.Forecast, error) {
+		forecast, err := member.provider.GetForecast(ctx, member.zoneFor(zone))
+		if err == nil && len(forecast.Points) == 0 {
+			err = errors.New("forecast has no points")
+		}
+
+		return forecast, err
+	})
+
+	if len(results) == 0 {
+		return nil, p.noMember(errs)
+	}
+
+	first := results[0].value
+	if p.mode == carbonv1alpha1.Failover {
+		forecast := *first
+		forecast.Zone = zone
+
+		return &forecast, nil
+	}
+
+	points := make([]common.ForecastPoint, 0, len(first.Points))
+	for _, point := range first.Points {
+		values := make([]weightedValue, 0, len(results))
+		for _, result := range results {
+			if value, ok := valueAt(result.value, point.PointTime); ok {
+				values = append(values, weightedValue{value: value, weight: result.member.weight})
+			}
+		}
+
+		points = append(points, common.ForecastPoint{PointTime: point.PointTime, Value: p.combine(values)})
+	}
+
+	forecast := common.NewForecast(
+		zone,
+		time.Now(),
+		first.Unit,
+		first.SignalType,
+		first.EmissionsType,
+		first.Resolution,
+		points,
+	)
+
+	return forecast, nil
+}
+
+// collect gets a value from the members in order, from the first one that
+// delivers in failover mode, or from all members otherwise. Values of members
+// that differ from the first one in unit or emissions type are dropped, as
+// they can not be combined.
+func collect[T *common.Reading | *common.Forecast](p *CompositeProvider, get func(member *compositeMember) (T, error)) ([]memberResult[T], []error) {
+	results := make([]memberResult[T], 0, len(p.members))
+	var errs []error
+
+	for i := range p.members {
+		member := &p.members[i]
+		if member.err != nil {
+			errs = append(errs, member.wrap(member.err))
+			continue
+		}
+
+		value, err := get(member)
+		if err == nil && value == nil {
+			err = errors.New("no data")
+		}
+		if err != nil {
+			errs = append(errs, member.wrap(err))
+			continue
+		}
+
+		if len(results) > 0 {
+			if err := compatible(results[0].value, value); err != nil {
+				errs = append(errs, member.wrap(err))
+				continue
+			}
+		}
+
+		results = append(results, memberResult[T]{member: member, value: value})
+		if p.mode == carbonv1alpha1.Failover {
+			break
+		}
+	}
+
+	return results, errs
+}
+
+// compatible checks that the value of a member can be combined with the value
+// of the first member.
+func compatible[T *common.Reading | *common.Forecast](first T, value T) error {
+	var firstUnit, unit common.Unit
+	var firstEmissionsType, emissionsType common.EmissionsType
+
+	switch f := any(first).(type) {
+	case *common.Reading:
+		v := any(value).(*common.Reading)
+		firstUnit, unit = f.Unit, v.Unit
+		firstEmissionsType, emissionsType = f.EmissionsType, v.EmissionsType
+	case *common.Forecast:
+		v := any(value).(*common.Forecast)
+		firstUnit, unit = f.Unit, v.Unit
+		firstEmissionsType, emissionsType = f.EmissionsType, v.EmissionsType
+	}
+
+	if unit != firstUnit {
+		return fmt.Errorf("unit %s differs from %s", unit, firstUnit)
+	}
+
+	if emissionsType != firstEmissionsType {
+		return fmt.Errorf("emissions type %s differs from %s", emissionsType, firstEmissionsType)
+	}
+
+	return nil
+}
+
+type weightedValue struct {
+	value  float64
+	weight float64
+}
+
+// combine returns the median or mean of an ensemble, or the weighted mean of a
+// blend. The weights of a blend are those of the members that delivered, so
+// that a missing member does not drag the value towards zero.
+func (p *CompositeProvider) combine(values []weightedValue) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	if p.mode == carbonv1alpha1.Ensemble && p.aggregation == carbonv1alpha1.Median {
+		sorted := make([]float64, 0, len(values))
+		for _, v := range values {
+			sorted = append(sorted, v.value)
+		}
+		sort.Float64s(sorted)
+
+		middle := len(sorted) / 2
+		if len(sorted)%2 == 0 {
+			return (sorted[middle-1] + sorted[middle]) / 2
+		}
+
+		return sorted[middle]
+	}
+
+	var sum, weights float64
+	for _, v := range values {
+		sum += v.value * v.weight
+		weights += v.weight
+	}
+
+	return sum / weights
+}
+
+// valueAt returns the value of the forecast point that covers the given time.
+func valueAt(forecast *common.Forecast, t time.Time) (float64, bool) {
+	i := sort.Search(len(forecast.Points), func(i int) bool {
+		return forecast.Points[i].PointTime.After(t)
+	})
+	if i == 0 {
+		return 0, false
+	}
+
+	point := forecast.Points[i-1]
+	if !point.PointTime.Equal(t) && t.Sub(point.PointTime) >= forecast.Resolution {
+		return 0, false
+	}
+
+	return point.Value, true
+}
+
+// noMember returns the error of a composite none of whose members delivered.
+func (p *CompositeProvider) noMember(errs []error) error {
+	if len(errs) == 0 {
+		return errors.New("no member delivered")
+	}
+
+	return fmt.Errorf("no member delivered: %w", errors.Join(errs...))
+}
+
+func (m *compositeMember) zoneFor(zone string) string {
+	if m.zone != "" {
+		return m.zone
+	}
+
+	return zone
+}
+
+// servedBy returns the member as the provider that delivered the reading, or
+// the providers that delivered it, if the member is a composite itself.
+func (m *compositeMember) servedBy(reading *common.Reading) []string {
+	if len(reading.ServedBy) > 0 {
+		return slices.Clone(reading.ServedBy)
+	}
+
+	return []string{m.name}
+}
+
+func (m *compositeMember) wrap(err error) error {
+	return fmt.Errorf("%s: %w", m.name, err)
+}
+
+// compositePath returns the composite provider objects that are being built,
+// as kind/namespace/name, to detect composites that reference themselves.
+type compositePath []string
+
+func (c compositePath) contains(namespace string, name string) bool {
+	return slices.Contains(c, strings.ToLower(fmt.Sprintf("%s/%s/%s", Composite, namespace, name)))
+}
+
+func (c compositePath) with(namespace string, name string) compositePath {
+	return append(slices.Clone(c), strings.ToLower(fmt.Sprintf("%s/%s/%s", Composite, namespace, name)))
+}
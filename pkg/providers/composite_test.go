@@ -0,0 +1,181 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	"github.com/rekuberate-io/carbon/pkg/common"
+	v1 "k8s.io/api/core/v1"
+)
+
+// stubProvider serves a constant carbon intensity per zone, and a forecast in
+// steps of its resolution, or fails if err is set.
+type stubProvider struct {
+	values     map[string]float64
+	resolution time.Duration
+	err        error
+}
+
+func (p *stubProvider) GetCapabilities(ctx context.Context) (*common.Capabilities, error) {
+	return &common.Capabilities{
+		Forecast:        true,
+		ForecastHorizon: 4 * p.resolution,
+		Resolution:      p.resolution,
+		EmissionsTypes:  []common.EmissionsType{common.Average},
+		ZoneNaming:      common.AnyZone,
+	}, nil
+}
+
+func (p *stubProvider) GetZones(ctx context.Context) ([]common.Zone, error) {
+	zones := make([]common.Zone, 0, len(p.values))
+	for zone := range p.values {
+		zones = append(zones, common.Zone{Name: zone})
+	}
+
+	return zones, nil
+}
+
+func (p *stubProvider) GetCurrent(ctx context.Context, zone string) (*common.Reading, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return &common.Reading{
+		Zone:          zone,
+		Value:         p.values[zone],
+		Unit:          common.GramsPerKilowattHour,
+		EmissionsType: common.Average,
+		PointTime:     time.Now(),
+	}, nil
+}
+
+func (p *stubProvider) GetForecast(ctx context.Context, zone string) (*common.Forecast, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	points := make([]common.ForecastPoint, 0)
+	for t := start; t.Before(start.Add(2 * time.Hour)); t = t.Add(p.resolution) {
+		points = append(points, common.ForecastPoint{PointTime: t, Value: p.values[zone]})
+	}
+
+	return common.NewForecast(zone, start, common.GramsPerKilowattHour, common.CarbonIntensity, common.Average, p.resolution, points), nil
+}
+
+func newTestComposite(mode carbonv1alpha1.CompositeMode, aggregation carbonv1alpha1.CompositeAggregation, members map[string]Provider, spec ...carbonv1alpha1.CompositeProviderMember) *CompositeProvider {
+	o := &carbonv1alpha1.CompositeProvider{Spec: carbonv1alpha1.CompositeProviderSpec{Mode: mode, Aggregation: aggregation, Members: spec}}
+	o.Namespace = "default"
+
+	return newCompositeProvider(o, func(m carbonv1alpha1.CompositeProviderMember) (Provider, error) {
+		p, ok := members[m.ProviderRef.Name]
+		if !ok {
+			return nil, errors.New("not found")
+		}
+
+		return p, nil
+	})
+}
+
+func member(name string, zone string, weight int32) carbonv1alpha1.CompositeProviderMember {
+	return carbonv1alpha1.CompositeProviderMember{
+		ProviderRef: &v1.ObjectReference{Kind: "Simulator", Name: name},
+		Zone:        zone,
+		Weight:      weight,
+	}
+}
+
+func TestCompositeFailover(t *testing.T) {
+	members := map[string]Provider{
+		"down":   &stubProvider{err: errors.New("service unavailable")},
+		"backup": &stubProvider{values: map[string]float64{"DE": 300}, resolution: time.Hour},
+	}
+	composite := newTestComposite(carbonv1alpha1.Failover, "", members,
+		member("missing", "", 0), member("down", "", 0), member("backup", "", 0))
+
+	reading, err := composite.GetCurrent(context.Background(), "DE")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reading.Value != 300 || !slices.Equal(reading.ServedBy, []string{"Simulator/default/backup"}) {
+		t.Errorf("reading = %v served by %v, want 300 served by the backup", reading.Value, reading.ServedBy)
+	}
+
+	members["backup"] = &stubProvider{err: errors.New("timeout")}
+	composite = newTestComposite(carbonv1alpha1.Failover, "", members, member("down", "", 0), member("backup", "", 0))
+	if _, err := composite.GetCurrent(context.Background(), "DE"); err == nil {
+		t.Error("expected an error when no member delivers")
+	}
+}
+
+func TestCompositeEnsemble(t *testing.T) {
+	members := map[string]Provider{
+		"a": &stubProvider{values: map[string]float64{"DE": 100}, resolution: time.Hour},
+		"b": &stubProvider{values: map[string]float64{"DE": 200}, resolution: time.Hour},
+		"c": &stubProvider{values: map[string]float64{"DE": 600}, resolution: time.Hour},
+	}
+
+	tests := []struct {
+		aggregation carbonv1alpha1.CompositeAggregation
+		want        float64
+	}{
+		{aggregation: carbonv1alpha1.Median, want: 200},
+		{aggregation: carbonv1alpha1.Mean, want: 300},
+	}
+
+	for _, tt := range tests {
+		composite := newTestComposite(carbonv1alpha1.Ensemble, tt.aggregation, members, member("a", "", 0), member("b", "", 0), member("c", "", 0))
+
+		reading, err := composite.GetCurrent(context.Background(), "DE")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if reading.Value != tt.want || len(reading.ServedBy) != 3 {
+			t.Errorf("%s: reading = %v served by %v, want %v served by all members", tt.aggregation, reading.Value, reading.ServedBy, tt.want)
+		}
+	}
+}
+
+func TestCompositeWeightedBlend(t *testing.T) {
+	members := map[string]Provider{
+		"hourly":   &stubProvider{values: map[string]float64{"DE": 400, "NL": 100}, resolution: time.Hour},
+		"halfhour": &stubProvider{values: map[string]float64{"FR": 50}, resolution: 30 * time.Minute},
+	}
+	composite := newTestComposite(carbonv1alpha1.WeightedBlend, "", members,
+		member("hourly", "DE", 50), member("hourly", "NL", 30), member("halfhour", "FR", 20))
+
+	if valid, err := composite.IsValidZone(context.Background(), "site-a"); err != nil || !valid {
+		t.Errorf("site zone rejected: %v", err)
+	}
+
+	reading, err := composite.GetCurrent(context.Background(), "site-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// (400*50 + 100*30 + 50*20) / 100
+	if reading.Value != 240 || reading.Zone != "site-a" {
+		t.Errorf("reading = %v for %s, want 240 for site-a", reading.Value, reading.Zone)
+	}
+
+	forecast, err := composite.GetForecast(context.Background(), "site-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(forecast.Points) != 2 {
+		t.Fatalf("forecast has %d points, want the 2 hourly points of the first member", len(forecast.Points))
+	}
+
+	for _, point := range forecast.Points {
+		if point.Value != 240 {
+			t.Errorf("point %s = %v, want 240", point.PointTime, point.Value)
+		}
+	}
+}
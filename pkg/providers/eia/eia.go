@@ -0,0 +1,406 @@
+package eia
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	"github.com/rekuberate-io/carbon/pkg/common"
+	"github.com/rekuberate-io/carbon/pkg/emissionfactors"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	eiaBaseUrl         = "https://api.eia.gov/v2"
+	apiKeyKey          = "apiKey"
+	resolution         = time.Hour
+	actualWindow       = 48 * time.Hour
+	pageLength         = 5000
+	maxResponsePayload = 20 << 20
+
+	// credentialsCheckBalancingAuthority is the balancing authority the API
+	// key is checked against
+	credentialsCheckBalancingAuthority = "CISO"
+)
+
+type EIAProvider struct {
+	baseUrl         string
+	apiKey          string
+	factors         *emissionfactors.Set
+	emissionFactors map[string]float64
+	client          *http.Client
+}
+
+// NewProvider returns a provider for the EIA object, authenticated with the
+// API key in the given Secret. Fuel types take the emission factors of their
+// fuels from the given set, or the default one if it is nil; the emission
+// factors of the object override them.
+func NewProvider(o carbonv1alpha1.EIA, secret *corev1.Secret, factors *emissionfactors.Set) (*EIAProvider, error) {
+	apiKey := string(secret.Data[apiKeyKey])
+	if apiKey == "" {
+		return nil, fmt.Errorf("key '%s' is missing in secret '%s'", apiKeyKey, secret.Name)
+	}
+
+	p := &EIAProvider{
+		baseUrl:         eiaBaseUrl,
+		apiKey:          apiKey,
+		factors:         factors,
+		emissionFactors: map[string]float64{},
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+
+	if o.Spec.Endpoint != nil && *o.Spec.Endpoint != "" {
+		p.baseUrl = *o.Spec.Endpoint
+	}
+
+	if p.factors == nil {
+		p.factors = emissionfactors.Default()
+	}
+
+	codes := map[string]string{}
+	for code, fuelType := range fuelTypes {
+		codes[fuelType.name] = code
+	}
+
+	for name, value := range o.Spec.EmissionFactors {
+		code, ok := codes[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown fuel type '%s'", name)
+		}
+
+		emissionFactor, err := strconv.ParseFloat(string(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("emission factor of '%s': %w", name, err)
+		}
+
+		p.emissionFactors[code] = emissionFactor
+	}
+
+	return p, nil
+}
+
+// GetCapabilities returns the capabilities of the Hourly Electric Grid
+// Monitor; carbon intensities are averages computed with the emission factors
+// of the fuel types, generation is not forecast.
+func (p *EIAProvider) GetCapabilities(ctx context.Context) (*common.Capabilities, error) {
+	return &common.Capabilities{
+		Forecast:       false,
+		Resolution:     resolution,
+		EmissionsTypes: []common.EmissionsType{common.Average},
+		ZoneNaming:     common.BalancingAuthority,
+	}, nil
+}
+
+// GetZones returns the balancing authorities by their EIA codes.
+func (p *EIAProvider) GetZones(ctx context.Context) ([]common.Zone, error) {
+	zones := make([]common.Zone, 0, len(balancingAuthorities))
+	for code, name := range balancingAuthorities {
+		zones = append(zones, common.Zone{Name: code, DisplayName: name})
+	}
+
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].Name < zones[j].Name
+	})
+
+	return zones, nil
+}
+
+// IsValidZone reports whether the zone is the EIA code or the WattTime
+// abbreviation of a balancing authority.
+func (p *EIAProvider) IsValidZone(ctx context.Context, zone string) (bool, error) {
+	_, ok := getBalancingAuthority(zone)
+	return ok, nil
+}
+
+// CheckCredentials verifies the API key by requesting the generation of a
+// single hour.
+func (p *EIAProvider) CheckCredentials(ctx context.Context) error {
+	end := time.Now().UTC().Truncate(time.Hour)
+	_, err := p.getData(ctx, credentialsCheckBalancingAuthority, end.Add(-time.Hour), end)
+
+	return err
+}
+
+// GetCurrent returns the carbon intensity of the latest hour all fuel types
+// reported their generation for.
+func (p *EIAProvider) GetCurrent(ctx context.Context, zone string) (*common.Reading, error) {
+	balancingAuthority, ok := getBalancingAuthority(zone)
+	if !ok {
+		return nil, fmt.Errorf("unknown zone '%s'", zone)
+	}
+
+	mix, pointTime, err := p.getLatestMix(ctx, balancingAuthority)
+	if err != nil {
+		return nil, err
+	}
+
+	carbonIntensity, _ := p.intensity(balancingAuthority, mix[pointTime])
+	reading := &common.Reading{
+		Zone:               zone,
+		Value:              carbonIntensity,
+		Unit:               common.GramsPerKilowattHour,
+		SignalType:         common.CarbonIntensity,
+		EmissionsType:      common.Average,
+		PointTime:          pointTime,
+		EmissionFactorType: p.factors.Type(),
+		Frequency:          resolution,
+	}
+
+	return reading, nil
+}
+
+// GetForecast is not supported, the EIA forecasts the demand of balancing
+// authorities but not their generation per fuel type.
+func (p *EIAProvider) GetForecast(ctx context.Context, zone string) (*common.Forecast, error) {
+	return nil, errors.New("the EIA does not publish generation forecasts")
+}
+
+// GetHistory returns the carbon intensity of every hour between start and end
+// that all fuel types reported their generation for.
+func (p *EIAProvider) GetHistory(ctx context.Context, zone string, start time.Time, end time.Time) ([]common.Reading, error) {
+	balancingAuthority, ok := getBalancingAuthority(zone)
+	if !ok {
+		return nil, fmt.Errorf("unknown zone '%s'", zone)
+	}
+
+	data, err := p.getData(ctx, balancingAuthority, start.UTC().Truncate(time.Hour), end)
+	if err != nil {
+		return nil, err
+	}
+
+	mix, err := newGenerationMix(data)
+	if err != nil {
+		return nil, err
+	}
+
+	readings := make([]common.Reading, 0, len(mix))
+	for _, t := range mix.completeTimes() {
+		if t.Before(start) || t.After(end) {
+			continue
+		}
+
+		carbonIntensity, ok := p.intensity(balancingAuthority, mix[t])
+		if !ok {
+			continue
+		}
+
+		readings = append(readings, common.Reading{
+			Zone:               zone,
+			Value:              carbonIntensity,
+			Unit:               common.GramsPerKilowattHour,
+			SignalType:         common.CarbonIntensity,
+			EmissionsType:      common.Average,
+			PointTime:          t,
+			EmissionFactorType: p.factors.Type(),
+			Frequency:          resolution,
+		})
+	}
+
+	return readings, nil
+}
+
+// GetPowerBreakdown returns the generation per fuel type of the latest hour
+// all fuel types reported for.
+func (p *EIAProvider) GetPowerBreakdown(ctx context.Context, zone string) (*common.PowerBreakdown, error) {
+	balancingAuthority, ok := getBalancingAuthority(zone)
+	if !ok {
+		return nil, fmt.Errorf("unknown zone '%s'", zone)
+	}
+
+	mix, pointTime, err := p.getLatestMix(ctx, balancingAuthority)
+	if err != nil {
+		return nil, err
+	}
+
+	breakdown := &common.PowerBreakdown{
+		Zone:       zone,
+		PointTime:  pointTime,
+		Production: map[string]float64{},
+	}
+
+	var renewable, fossilFree float64
+	for code, power := range mix[pointTime] {
+		if power <= 0 {
+			continue
+		}
+
+		fuelType, ok := fuelTypes[code]
+		if !ok {
+			fuelType = fuelTypes[otherFuelType]
+		}
+
+		breakdown.Production[fuelType.name] += power
+		breakdown.ProductionTotal += power
+		if fuelType.renewable {
+			renewable += power
+		}
+		if fuelType.fossilFree {
+			fossilFree += power
+		}
+	}
+
+	if breakdown.ProductionTotal > 0 {
+		renewablePercentage := 100 * renewable / breakdown.ProductionTotal
+		fossilFreePercentage := 100 * fossilFree / breakdown.ProductionTotal
+		breakdown.RenewablePercentage = &renewablePercentage
+		breakdown.FossilFreePercentage = &fossilFreePercentage
+	}
+
+	return breakdown, nil
+}
+
+// getLatestMix returns the generation of the balancing authority in the last
+// two days along with the start of its latest complete hour.
+func (p *EIAProvider) getLatestMix(ctx context.Context, balancingAuthority string) (generationMix, time.Time, error) {
+	end := time.Now().UTC().Truncate(time.Hour)
+	data, err := p.getData(ctx, balancingAuthority, end.Add(-actualWindow), end)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	mix, err := newGenerationMix(data)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	times := mix.completeTimes()
+	for i := len(times) - 1; i >= 0; i-- {
+		if _, ok := p.intensity(balancingAuthority, mix[times[i]]); ok {
+			return mix, times[i], nil
+		}
+	}
+
+	return nil, time.Time{}, fmt.Errorf("no generation of balancing authority '%s' was reported within the last %s", balancingAuthority, actualWindow)
+}
+
+// intensity returns the carbon intensity of the generation of a balancing
+// authority in an hour, or false if nothing was generated. Storage that is
+// charging reports a negative generation and is left out.
+func (p *EIAProvider) intensity(balancingAuthority string, generation map[string]float64) (float64, bool) {
+	var total, emissions float64
+	for code, power := range generation {
+		if power <= 0 {
+			continue
+		}
+
+		total += power
+		emissions += power * p.emissionFactor(balancingAuthority, code)
+	}
+
+	if total == 0 {
+		return 0, false
+	}
+
+	return emissions / total, true
+}
+
+// emissionFactor returns the emission factor of a fuel type within a balancing
+// authority, which is the one of its fuel unless the object overrides it. Zone
+// overrides of the EmissionFactorSet are looked up by the EIA code of the
+// balancing authority, whichever alias the issuer uses for it.
+func (p *EIAProvider) emissionFactor(balancingAuthority string, code string) float64 {
+	if emissionFactor, ok := p.emissionFactors[code]; ok {
+		return emissionFactor
+	}
+
+	fuelType, ok := fuelTypes[code]
+	if !ok {
+		fuelType = fuelTypes[otherFuelType]
+	}
+
+	return p.factors.Factor(balancingAuthority, fuelType.fuel)
+}
+
+// getData returns the hourly generation per fuel type of the balancing
+// authority for the hours ending between start and end, requesting one page
+// after the other.
+func (p *EIAProvider) getData(ctx context.Context, balancingAuthority string, start time.Time, end time.Time) ([]FuelTypeData, error) {
+	params := url.Values{}
+	params.Set("frequency", "hourly")
+	params.Set("data[0]", "value")
+	params.Set("facets[respondent][]", balancingAuthority)
+	params.Set("start", start.UTC().Format(periodLayout))
+	params.Set("end", end.UTC().Format(periodLayout))
+	params.Set("sort[0][column]", "period")
+	params.Set("sort[0][direction]", "desc")
+	params.Set("length", strconv.Itoa(pageLength))
+
+	data := make([]FuelTypeData, 0)
+	for {
+		params.Set("offset", strconv.Itoa(len(data)))
+		result, err := p.get(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		data = append(data, result.Response.Data...)
+
+		total, err := result.Response.Total.Int64()
+		if err != nil || len(result.Response.Data) == 0 || int64(len(data)) >= total {
+			return data, nil
+		}
+	}
+}
+
+func (p *EIAProvider) get(ctx context.Context, params url.Values) (*FuelTypeDataResponse, error) {
+	requestUrl, err := url.Parse(p.baseUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	requestUrl = requestUrl.JoinPath("electricity", "rto", "fuel-type-data", "data")
+	params.Set("api_key", p.apiKey)
+	requestUrl.RawQuery = params.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("%w: %s", common.ErrInvalidCredentials, response.Status)
+	}
+
+	bytes, err := io.ReadAll(io.LimitReader(response.Body, maxResponsePayload))
+	if err != nil {
+		return nil, err
+	}
+
+	// failed requests come with a JSON body of their own, e.g. for an
+	// unknown facet
+	result := &FuelTypeDataResponse{}
+	if err := json.Unmarshal(bytes, result); err != nil {
+		if response.StatusCode != http.StatusOK {
+			return nil, errors.New(response.Status)
+		}
+
+		return nil, err
+	}
+
+	if message := result.errorMessage(); message != "" {
+		return nil, fmt.Errorf("request was rejected: %s", message)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.New(response.Status)
+	}
+
+	return result, nil
+}
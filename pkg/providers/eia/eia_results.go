@@ -0,0 +1,144 @@
+package eia
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// periodLayout is the layout of hourly periods in UTC, e.g. 2024-03-01T05
+const periodLayout = "2006-01-02T15"
+
+// FuelTypeDataResponse is the response of the fuel-type-data route, or an
+// error if the request failed.
+type FuelTypeDataResponse struct {
+	Response struct {
+		Total     json.Number    `json:"total"`
+		Frequency string         `json:"frequency"`
+		Data      []FuelTypeData `json:"data"`
+	} `json:"response"`
+	Error json.RawMessage `json:"error,omitempty"`
+}
+
+// FuelTypeData is the net generation of a fuel type within the hour ending at
+// the period, in MWh.
+type FuelTypeData struct {
+	Period     string   `json:"period"`
+	Respondent string   `json:"respondent"`
+	FuelType   string   `json:"fueltype"`
+	TypeName   string   `json:"type-name"`
+	Value      Quantity `json:"value"`
+	ValueUnits string   `json:"value-units"`
+}
+
+// Quantity is a value that the API returns either as number or as string, and
+// as null if it was not reported.
+type Quantity struct {
+	Value float64
+	Valid bool
+}
+
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case nil:
+		*q = Quantity{}
+	case float64:
+		*q = Quantity{Value: v, Valid: true}
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("quantity '%s': %w", v, err)
+		}
+
+		*q = Quantity{Value: f, Valid: true}
+	default:
+		return fmt.Errorf("quantity of unexpected type %T", value)
+	}
+
+	return nil
+}
+
+// errorMessage returns the message of an error, which the API returns either
+// as string or as object with a code and message.
+func (r *FuelTypeDataResponse) errorMessage() string {
+	if len(r.Error) == 0 || string(r.Error) == "null" {
+		return ""
+	}
+
+	var message string
+	if err := json.Unmarshal(r.Error, &message); err == nil {
+		return message
+	}
+
+	var e struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(r.Error, &e); err == nil && e.Message != "" {
+		return fmt.Sprintf("%s (%s)", e.Message, e.Code)
+	}
+
+	return string(r.Error)
+}
+
+// generationMix is the power generated per fuel type, in MW, for each hour.
+type generationMix map[time.Time]map[string]float64
+
+// newGenerationMix collects the data by the start of their hours, as the API
+// reports hourly data by the end of the hour.
+func newGenerationMix(data []FuelTypeData) (generationMix, error) {
+	mix := generationMix{}
+	for _, d := range data {
+		if !d.Value.Valid {
+			continue
+		}
+
+		end, err := time.Parse(periodLayout, d.Period)
+		if err != nil {
+			return nil, fmt.Errorf("period '%s': %w", d.Period, err)
+		}
+
+		start := end.Add(-time.Hour)
+		hour, ok := mix[start]
+		if !ok {
+			hour = map[string]float64{}
+			mix[start] = hour
+		}
+
+		hour[d.FuelType] += d.Value.Value
+	}
+
+	return mix, nil
+}
+
+// completeTimes returns the sorted start times of the hours that all fuel
+// types of the mix reported for. Balancing authorities report with different
+// delays, so the latest hours are often incomplete.
+func (m generationMix) completeTimes() []time.Time {
+	reported := map[string]bool{}
+	for _, hour := range m {
+		for fuelType := range hour {
+			reported[fuelType] = true
+		}
+	}
+
+	times := make([]time.Time, 0, len(m))
+	for t, hour := range m {
+		if len(hour) == len(reported) {
+			times = append(times, t)
+		}
+	}
+
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+
+	return times
+}
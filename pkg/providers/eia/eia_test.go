@@ -0,0 +1,175 @@
+package eia_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	"github.com/rekuberate-io/carbon/pkg/common"
+	"github.com/rekuberate-io/carbon/pkg/emissionfactors"
+	"github.com/rekuberate-io/carbon/pkg/providers/eia"
+	"github.com/rekuberate-io/carbon/pkg/providers/eia/eiatest"
+	"github.com/rekuberate-io/carbon/pkg/providers/providertest"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const apiKey = "b7e4c1a9d2f04e6b8a3c5d7e9f1a2b4c"
+
+// cisoGeneration is the hourly generation of the California ISO per EIA fuel
+// type in MWh, while its batteries are charging
+var cisoGeneration = map[string]float64{"NG": 8000, "SUN": 6000, "WND": 2000, "NUC": 2000, "WAT": 1000, "BAT": -500}
+
+// newTestServer starts a stand-in reporting cisoGeneration for CISO only, with
+// hydro lagging an hour behind as it often does.
+func newTestServer(t *testing.T) *eiatest.Server {
+	server := eiatest.NewServer(apiKey, map[string]map[string]float64{"CISO": cisoGeneration})
+	server.Delay["WAT"] = 1
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// newTestProvider returns a provider of an EIA object with the given spec and
+// API key, and the emission factors of the set, reading from the stand-in.
+func newTestProvider(t *testing.T, server *eiatest.Server, key string, spec carbonv1alpha1.EIASpec, factors *emissionfactors.Set) *eia.EIAProvider {
+	spec.Endpoint = &server.URL
+	secret := &corev1.Secret{Data: map[string][]byte{"apiKey": []byte(key)}}
+
+	provider, err := eia.NewProvider(carbonv1alpha1.EIA{Spec: spec}, secret, factors)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return provider
+}
+
+func TestGetCurrent(t *testing.T) {
+	server := newTestServer(t)
+	provider := newTestProvider(t, server, apiKey, carbonv1alpha1.EIASpec{}, nil)
+
+	// the latest hour lacks hydro, so the reading is of the hour before:
+	// (8000*490 + 6000*45 + 2000*11 + 2000*12 + 1000*24) / 19000, leaving out
+	// the charging battery
+	for _, zone := range []string{"CISO", "CAISO_NORTH"} {
+		reading, err := provider.GetCurrent(context.Background(), zone)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !providertest.Near(reading.Value, 224.21) || reading.Zone != zone {
+			t.Errorf("reading = %v for %s, want 224.21 for %s", reading.Value, reading.Zone, zone)
+		}
+	}
+
+	if _, err := provider.GetCurrent(context.Background(), "PJM"); err == nil {
+		t.Error("expected an error for a zone without any reported generation")
+	}
+
+	if _, err := provider.GetCurrent(context.Background(), "DE"); err == nil {
+		t.Error("expected an error for an unknown zone")
+	}
+}
+
+func TestPaging(t *testing.T) {
+	server := newTestServer(t)
+	server.MaxPageLength = 50
+	provider := newTestProvider(t, server, apiKey, carbonv1alpha1.EIASpec{}, nil)
+
+	reading, err := provider.GetCurrent(context.Background(), "CISO")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !providertest.Near(reading.Value, 224.21) {
+		t.Errorf("reading = %v, want 224.21", reading.Value)
+	}
+}
+
+func TestGetPowerBreakdown(t *testing.T) {
+	server := newTestServer(t)
+	provider := newTestProvider(t, server, apiKey, carbonv1alpha1.EIASpec{}, nil)
+
+	breakdown, err := provider.GetPowerBreakdown(context.Background(), "CISO")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if breakdown.ProductionTotal != 19000 || breakdown.Production["naturalGas"] != 8000 || breakdown.Production["battery"] != 0 {
+		t.Errorf("production = %v, want 19000 in total", breakdown.Production)
+	}
+
+	if breakdown.RenewablePercentage == nil || !providertest.Near(*breakdown.RenewablePercentage, 900.0/19) {
+		t.Errorf("renewable percentage = %v, want %v", breakdown.RenewablePercentage, 900.0/19)
+	}
+}
+
+func TestEmissionFactors(t *testing.T) {
+	server := newTestServer(t)
+	provider := newTestProvider(t, server, apiKey, carbonv1alpha1.EIASpec{
+		EmissionFactors: map[string]carbonv1alpha1.EmissionFactor{"naturalGas": "400"},
+	}, nil)
+
+	reading, err := provider.GetCurrent(context.Background(), "CISO")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 720000 less than with the default factor of natural gas
+	if !providertest.Near(reading.Value, 186.32) {
+		t.Errorf("reading = %v, want 186.32", reading.Value)
+	}
+}
+
+func TestInvalidApiKey(t *testing.T) {
+	server := newTestServer(t)
+	provider := newTestProvider(t, server, "invalid", carbonv1alpha1.EIASpec{}, nil)
+
+	if err := provider.CheckCredentials(context.Background()); !errors.Is(err, common.ErrInvalidCredentials) {
+		t.Errorf("err = %v, want %v", err, common.ErrInvalidCredentials)
+	}
+}
+
+func TestGetForecast(t *testing.T) {
+	server := newTestServer(t)
+	provider := newTestProvider(t, server, apiKey, carbonv1alpha1.EIASpec{}, nil)
+
+	if _, err := provider.GetForecast(context.Background(), "CISO"); err == nil {
+		t.Error("expected an error, as generation is not forecast")
+	}
+}
+
+func TestEmissionFactorSet(t *testing.T) {
+	server := newTestServer(t)
+	set, err := emissionfactors.NewSet(&carbonv1alpha1.EmissionFactorSet{Spec: carbonv1alpha1.EmissionFactorSetSpec{
+		Type:  carbonv1alpha1.Direct,
+		Zones: []carbonv1alpha1.ZoneEmissionFactors{{Zone: "CISO", Factors: map[string]carbonv1alpha1.EmissionFactor{"gas": "400"}}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider := newTestProvider(t, server, apiKey, carbonv1alpha1.EIASpec{}, set)
+
+	// only natural gas emits directly, at 400 within CISO whichever alias
+	// names it
+	tests := []struct {
+		zone string
+		want float64
+	}{
+		{zone: "CISO", want: 168.42},
+		{zone: "CAISO_NORTH", want: 168.42},
+		{zone: "CAISO_SOUTH", want: 168.42},
+	}
+
+	for _, tt := range tests {
+		reading, err := provider.GetCurrent(context.Background(), tt.zone)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !providertest.Near(reading.Value, tt.want) || reading.EmissionFactorType != common.Direct {
+			t.Errorf("%s: reading = %v %s, want %v %s", tt.zone, reading.Value, reading.EmissionFactorType, tt.want, common.Direct)
+		}
+	}
+}
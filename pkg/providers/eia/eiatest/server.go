@@ -0,0 +1,125 @@
+// Package eiatest provides a stand-in for the hourly fuel type data of the EIA
+// Open Data API, for tests that must not reach the public API.
+package eiatest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	periodLayout  = "2006-01-02T15"
+	maxPageLength = 5000
+)
+
+// Server is a stand-in of the fuel-type-data route. It reports the same
+// generation for every hour of the requested period, as strings like the API
+// does.
+type Server struct {
+	*httptest.Server
+
+	// ApiKey is required on every request
+	ApiKey string
+	// Generation maps a respondent to the generation of each of its fuel
+	// types per hour, in MWh
+	Generation map[string]map[string]float64
+	// Delay is the number of latest hours a fuel type has not reported yet
+	Delay map[string]int
+	// MaxPageLength caps the number of data per page, it defaults to the
+	// 5000 of the API
+	MaxPageLength int
+}
+
+// NewServer starts a stand-in of the fuel-type-data route accepting the given
+// API key. The caller must close the server.
+func NewServer(apiKey string, generation map[string]map[string]float64) *Server {
+	s := &Server{ApiKey: apiKey, Generation: generation, Delay: map[string]int{}, MaxPageLength: maxPageLength}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/electricity/rto/fuel-type-data/data", s.data)
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+func (s *Server) data(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("api_key") != s.ApiKey {
+		writeError(w, http.StatusForbidden, map[string]string{
+			"code":    "API_KEY_INVALID",
+			"message": "An invalid api_key has been supplied. Please check the key and try again.",
+		})
+		return
+	}
+
+	start, err := time.Parse(periodLayout, query.Get("start"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid start: "+query.Get("start"))
+		return
+	}
+
+	end, err := time.Parse(periodLayout, query.Get("end"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid end: "+query.Get("end"))
+		return
+	}
+
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	length, err := strconv.Atoi(query.Get("length"))
+	if err != nil || length > s.MaxPageLength {
+		length = s.MaxPageLength
+	}
+
+	respondent := query.Get("facets[respondent][]")
+	generation := s.Generation[respondent]
+	fuelTypes := make([]string, 0, len(generation))
+	for fuelType := range generation {
+		fuelTypes = append(fuelTypes, fuelType)
+	}
+	sort.Strings(fuelTypes)
+
+	// newest first, both ends included
+	data := make([]map[string]any, 0)
+	for period := end; !period.Before(start); period = period.Add(-time.Hour) {
+		for _, fuelType := range fuelTypes {
+			if period.After(end.Add(-time.Duration(s.Delay[fuelType]) * time.Hour)) {
+				continue
+			}
+
+			data = append(data, map[string]any{
+				"period":      period.Format(periodLayout),
+				"respondent":  respondent,
+				"fueltype":    fuelType,
+				"value":       strconv.FormatFloat(generation[fuelType], 'f', -1, 64),
+				"value-units": "megawatthours",
+			})
+		}
+	}
+
+	total := len(data)
+	data = data[min(offset, total):min(offset+length, total)]
+
+	write(w, map[string]any{
+		"response": map[string]any{
+			"total":      strconv.Itoa(total),
+			"dateFormat": `YYYY-MM-DD"T"HH24`,
+			"frequency":  "hourly",
+			"data":       data,
+		},
+		"apiVersion": "2.1.8",
+	})
+}
+
+func write(w http.ResponseWriter, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(payload)
+}
+
+func writeError(w http.ResponseWriter, status int, e any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{"error": e, "code": status})
+}
@@ -0,0 +1,36 @@
+package eia
+
+import (
+	"github.com/rekuberate-io/carbon/pkg/emissionfactors"
+)
+
+// fuelType is a fuel type of the Hourly Electric Grid Monitor along with the
+// fuel it takes its emission factor from.
+type fuelType struct {
+	name       string
+	fuel       emissionfactors.Fuel
+	renewable  bool
+	fossilFree bool
+}
+
+// otherFuelType is the code of the fuel type that generation of unknown fuel
+// types is attributed to
+const otherFuelType = "OTH"
+
+// fuelTypes maps the fueltype codes to fuel types. Batteries and pumped
+// storage count as storage, solar with batteries as solar.
+var fuelTypes = map[string]fuelType{
+	"COL": {name: "coal", fuel: emissionfactors.Coal},
+	"NG":  {name: "naturalGas", fuel: emissionfactors.Gas},
+	"NUC": {name: "nuclear", fuel: emissionfactors.Nuclear, fossilFree: true},
+	"OIL": {name: "oil", fuel: emissionfactors.Oil},
+	"WAT": {name: "hydro", fuel: emissionfactors.Hydro, renewable: true, fossilFree: true},
+	"SUN": {name: "solar", fuel: emissionfactors.Solar, renewable: true, fossilFree: true},
+	"WND": {name: "wind", fuel: emissionfactors.Wind, renewable: true, fossilFree: true},
+	"GEO": {name: "geothermal", fuel: emissionfactors.Geothermal, renewable: true, fossilFree: true},
+	"BAT": {name: "battery", fuel: emissionfactors.Storage, fossilFree: true},
+	"PS":  {name: "pumpedStorage", fuel: emissionfactors.Storage, fossilFree: true},
+	"SNB": {name: "solarWithBattery", fuel: emissionfactors.Solar, renewable: true, fossilFree: true},
+	"OTH": {name: "other", fuel: emissionfactors.Other},
+	"UNK": {name: "unknown", fuel: emissionfactors.Other},
+}
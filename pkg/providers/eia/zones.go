@@ -0,0 +1,93 @@
+package eia
+
+import (
+	"strings"
+)
+
+// balancingAuthorities maps the EIA codes of the balancing authorities that
+// report to the Hourly Electric Grid Monitor to their names.
+var balancingAuthorities = map[string]string{
+	"AECI": "Associated Electric Cooperative, Inc.",
+	"AVA":  "Avista Corporation",
+	"AZPS": "Arizona Public Service Company",
+	"BANC": "Balancing Authority of Northern California",
+	"BPAT": "Bonneville Power Administration",
+	"CHPD": "Public Utility District No. 1 of Chelan County",
+	"CISO": "California Independent System Operator",
+	"CPLE": "Duke Energy Progress East",
+	"CPLW": "Duke Energy Progress West",
+	"DOPD": "PUD No. 1 of Douglas County",
+	"DUK":  "Duke Energy Carolinas",
+	"EPE":  "El Paso Electric Company",
+	"ERCO": "Electric Reliability Council of Texas, Inc.",
+	"FMPP": "Florida Municipal Power Pool",
+	"FPC":  "Duke Energy Florida, Inc.",
+	"FPL":  "Florida Power & Light Co.",
+	"GCPD": "Public Utility District No. 2 of Grant County",
+	"GVL":  "Gainesville Regional Utilities",
+	"HST":  "City of Homestead",
+	"IID":  "Imperial Irrigation District",
+	"IPCO": "Idaho Power Company",
+	"ISNE": "ISO New England",
+	"JEA":  "JEA",
+	"LDWP": "Los Angeles Department of Water and Power",
+	"LGEE": "Louisville Gas and Electric Company and Kentucky Utilities Company",
+	"MISO": "Midcontinent Independent System Operator, Inc.",
+	"NEVP": "Nevada Power Company",
+	"NWMT": "NorthWestern Corporation",
+	"NYIS": "New York Independent System Operator",
+	"PACE": "PacifiCorp East",
+	"PACW": "PacifiCorp West",
+	"PGE":  "Portland General Electric Company",
+	"PJM":  "PJM Interconnection, LLC",
+	"PNM":  "Public Service Company of New Mexico",
+	"PSCO": "Public Service Company of Colorado",
+	"PSEI": "Puget Sound Energy, Inc.",
+	"SC":   "South Carolina Public Service Authority",
+	"SCEG": "Dominion Energy South Carolina, Inc.",
+	"SCL":  "Seattle City Light",
+	"SEC":  "Seminole Electric Cooperative",
+	"SOCO": "Southern Company Services, Inc. - Trans",
+	"SPA":  "Southwestern Power Administration",
+	"SRP":  "Salt River Project Agricultural Improvement and Power District",
+	"SWPP": "Southwest Power Pool",
+	"TAL":  "City of Tallahassee",
+	"TEC":  "Tampa Electric Company",
+	"TEPC": "Tucson Electric Power",
+	"TIDC": "Turlock Irrigation District",
+	"TPWR": "City of Tacoma, Department of Public Utilities, Light Division",
+	"TVA":  "Tennessee Valley Authority",
+	"WACM": "Western Area Power Administration - Rocky Mountain Region",
+	"WALC": "Western Area Power Administration - Desert Southwest Region",
+	"WAUW": "Western Area Power Administration - Upper Great Plains West",
+}
+
+// wattTimeAliases maps the balancing authority abbreviations of WattTime that
+// differ from the EIA codes; abbreviations ending with an underscore are
+// prefixes of the sub-regions of an ISO, e.g. CAISO_NORTH.
+var wattTimeAliases = map[string]string{
+	"BPA":    "BPAT",
+	"CAISO_": "CISO",
+	"ERCOT_": "ERCO",
+	"ISONE_": "ISNE",
+	"MISO_":  "MISO",
+	"NYISO_": "NYIS",
+	"PJM_":   "PJM",
+	"SPP_":   "SWPP",
+}
+
+// getBalancingAuthority returns the EIA code of a zone, which is either an EIA
+// code itself or a balancing authority abbreviation of WattTime.
+func getBalancingAuthority(zone string) (string, bool) {
+	if _, ok := balancingAuthorities[zone]; ok {
+		return zone, true
+	}
+
+	for alias, code := range wattTimeAliases {
+		if zone == alias || (strings.HasSuffix(alias, "_") && strings.HasPrefix(zone, alias)) {
+			return code, true
+		}
+	}
+
+	return "", false
+}
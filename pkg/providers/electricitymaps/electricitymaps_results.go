@@ -21,3 +21,32 @@ type ForecastResult struct {
 	} `json:"forecast"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
+
+type HistoryResult struct {
+	Zone    string       `json:"zone"`
+	History []LiveResult `json:"history"`
+}
+
+type PastRangeResult struct {
+	Zone string       `json:"zone"`
+	Data []LiveResult `json:"data"`
+}
+
+type ZonesResult map[string]struct {
+	ZoneName    string `json:"zoneName"`
+	CountryName string `json:"countryName"`
+}
+
+type PowerBreakdownResult struct {
+	Zone                      string              `json:"zone"`
+	Datetime                  time.Time           `json:"datetime"`
+	UpdatedAt                 time.Time           `json:"updatedAt"`
+	PowerProductionBreakdown  map[string]*float64 `json:"powerProductionBreakdown"`
+	PowerConsumptionBreakdown map[string]*float64 `json:"powerConsumptionBreakdown"`
+	PowerProductionTotal      *float64            `json:"powerProductionTotal"`
+	PowerConsumptionTotal     *float64            `json:"powerConsumptionTotal"`
+	FossilFreePercentage      *float64            `json:"fossilFreePercentage"`
+	RenewablePercentage       *float64            `json:"renewablePercentage"`
+	IsEstimated               bool                `json:"isEstimated"`
+	EstimationMethod          string              `json:"estimationMethod"`
+}
@@ -0,0 +1,181 @@
+package electricitymaps
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	"github.com/rekuberate-io/carbon/pkg/common"
+	"github.com/rekuberate-io/carbon/pkg/providers/electricitymaps/electricitymapstest"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const apiKey = "Xq7mT2vLp9RkW4cN"
+
+func newTestServer(t *testing.T) *electricitymapstest.Server {
+	server := electricitymapstest.NewServer(apiKey, map[string]map[string]any{
+		"DK-DK1": {
+			"zone":     "DK-DK1",
+			"datetime": "2024-03-01T12:00:00Z",
+			"powerProductionBreakdown": map[string]any{
+				"wind": 2600, "solar": 150, "biomass": 450, "coal": 500, "gas": 300, "nuclear": 0, "geothermal": nil,
+			},
+			"powerProductionTotal": 4000,
+			"renewablePercentage":  80,
+			"fossilFreePercentage": 80,
+		},
+	})
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// newTestProvider returns a free tier provider that sends its requests to the
+// stand-in server.
+func newTestProvider(t *testing.T, server *electricitymapstest.Server, key string) *ElectricityMapsProvider {
+	o := carbonv1alpha1.ElectricityMaps{Spec: carbonv1alpha1.ElectricityMapsSpec{Subscription: string(FreeTier)}}
+	secret := &corev1.Secret{Data: map[string][]byte{"apiKey": []byte(key)}}
+
+	provider, err := NewProvider(o, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider.baseUrl, _ = url.Parse(server.URL)
+
+	return provider
+}
+
+func TestGetPowerBreakdown(t *testing.T) {
+	server := newTestServer(t)
+	provider := newTestProvider(t, server, apiKey)
+
+	breakdown, err := provider.GetPowerBreakdown(context.Background(), "DK-DK1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if breakdown.ProductionTotal != 4000 || breakdown.Production["wind"] != 2600 || breakdown.Production["coal"] != 500 {
+		t.Errorf("production = %v, want 4000 in total", breakdown.Production)
+	}
+
+	// sources without a reported production are left out
+	if _, ok := breakdown.Production["geothermal"]; ok || len(breakdown.Production) != 6 {
+		t.Errorf("production = %v, want the 6 reported sources", breakdown.Production)
+	}
+
+	if breakdown.RenewablePercentage == nil || *breakdown.RenewablePercentage != 80 {
+		t.Errorf("renewable percentage = %v, want 80", breakdown.RenewablePercentage)
+	}
+	if breakdown.FossilFreePercentage == nil || *breakdown.FossilFreePercentage != 80 {
+		t.Errorf("fossil-free percentage = %v, want 80", breakdown.FossilFreePercentage)
+	}
+
+	if want := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC); !breakdown.PointTime.Equal(want) {
+		t.Errorf("point time = %s, want %s", breakdown.PointTime, want)
+	}
+
+	if _, err := provider.GetPowerBreakdown(context.Background(), "DK-BHM"); err == nil {
+		t.Error("expected an error for a zone without a power breakdown")
+	}
+}
+
+func TestGetHistory(t *testing.T) {
+	server := newTestServer(t)
+	provider := newTestProvider(t, server, apiKey)
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		requests []string
+		value    float64
+	}{
+		{
+			name:     "last 24 hours",
+			start:    now.Add(-24 * time.Hour),
+			end:      now,
+			requests: []string{"/carbon-intensity/history"},
+			value:    electricitymapstest.HistoryIntensity,
+		},
+		{
+			name:     "last 6 hours",
+			start:    now.Add(-6 * time.Hour),
+			end:      now,
+			requests: []string{"/carbon-intensity/history"},
+			value:    electricitymapstest.HistoryIntensity,
+		},
+		{
+			name:     "last 36 hours",
+			start:    now.Add(-36 * time.Hour),
+			end:      now,
+			requests: []string{"/carbon-intensity/past-range"},
+			value:    electricitymapstest.PastRangeIntensity,
+		},
+		{
+			name:     "a day a week ago",
+			start:    now.Add(-8 * 24 * time.Hour),
+			end:      now.Add(-7 * 24 * time.Hour),
+			requests: []string{"/carbon-intensity/past-range"},
+			value:    electricitymapstest.PastRangeIntensity,
+		},
+		{
+			name:     "last 14 days",
+			start:    now.Add(-14 * 24 * time.Hour),
+			end:      now,
+			requests: []string{"/carbon-intensity/past-range", "/carbon-intensity/past-range"},
+			value:    electricitymapstest.PastRangeIntensity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server.Requests()
+
+			readings, err := provider.GetHistory(context.Background(), "DK-DK1", tt.start, tt.end)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if requests := server.Requests(); !slices.Equal(requests, tt.requests) {
+				t.Errorf("requests = %v, want %v", requests, tt.requests)
+			}
+
+			if len(readings) == 0 {
+				t.Fatal("got no readings")
+			}
+
+			for i, reading := range readings {
+				if reading.PointTime.Before(tt.start) || reading.PointTime.After(tt.end) || reading.Value != tt.value {
+					t.Errorf("reading %d = %v at %s, want %v between %s and %s", i, reading.Value, reading.PointTime, tt.value, tt.start, tt.end)
+				}
+				if i > 0 && !reading.PointTime.After(readings[i-1].PointTime) {
+					t.Errorf("reading %d at %s is not after the one before", i, reading.PointTime)
+				}
+			}
+		})
+	}
+}
+
+func TestInvalidApiKey(t *testing.T) {
+	server := newTestServer(t)
+	provider := newTestProvider(t, server, "invalid")
+
+	if _, err := provider.GetPowerBreakdown(context.Background(), "DK-DK1"); !errors.Is(err, common.ErrInvalidCredentials) {
+		t.Errorf("err = %v, want %v", err, common.ErrInvalidCredentials)
+	}
+}
+
+func TestUnknownSubscription(t *testing.T) {
+	o := carbonv1alpha1.ElectricityMaps{Spec: carbonv1alpha1.ElectricityMapsSpec{Subscription: "enterprise"}}
+	secret := &corev1.Secret{Data: map[string][]byte{"apiKey": []byte(apiKey)}}
+
+	if provider, err := NewProvider(o, secret); provider != nil || err == nil {
+		t.Errorf("provider = %v, %v, want an error", provider, err)
+	}
+}
@@ -0,0 +1,153 @@
+// Package electricitymapstest provides a stand-in for the ElectricityMaps API,
+// for tests that must not reach the public API.
+package electricitymapstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// FreeTierPath prefixes the routes of the free tier
+	FreeTierPath = "/free-tier"
+
+	// HistoryIntensity is the carbon intensity of every hour the history
+	// route serves
+	HistoryIntensity = 300
+	// PastRangeIntensity is the carbon intensity of every hour the past
+	// range route serves
+	PastRangeIntensity = 400
+
+	pastRangeMaxWindow = 10 * 24 * time.Hour
+)
+
+// Server is a stand-in of the carbon intensity and power breakdown routes,
+// of both the free tier and commercial subscriptions. It keeps the path of
+// every request, without the prefix of the free tier.
+type Server struct {
+	*httptest.Server
+
+	// ApiKey is required in the auth-token header of every request
+	ApiKey string
+	// Breakdown is the latest power breakdown of every zone
+	Breakdown map[string]map[string]any
+
+	mu       sync.Mutex
+	requests []string
+}
+
+// NewServer starts a stand-in of the API accepting the given API key. The
+// caller must close the server.
+func NewServer(apiKey string, breakdown map[string]map[string]any) *Server {
+	s := &Server{ApiKey: apiKey, Breakdown: breakdown}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/carbon-intensity/history", s.history)
+	mux.HandleFunc("/carbon-intensity/past-range", s.pastRange)
+	mux.HandleFunc("/power-breakdown/latest", s.powerBreakdown)
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.URL.Path = strings.TrimPrefix(r.URL.Path, FreeTierPath)
+
+		s.mu.Lock()
+		s.requests = append(s.requests, r.URL.Path)
+		s.mu.Unlock()
+
+		if r.Header.Get("auth-token") != s.ApiKey {
+			writeError(w, http.StatusUnauthorized, "Invalid auth-token")
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	}))
+
+	return s
+}
+
+// Requests returns the paths requested so far and forgets them.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requests := s.requests
+	s.requests = nil
+
+	return requests
+}
+
+// history serves the 24 hours up to now.
+func (s *Server) history(w http.ResponseWriter, r *http.Request) {
+	zone := r.URL.Query().Get("zone")
+	end := time.Now().UTC().Truncate(time.Hour)
+
+	history := make([]map[string]any, 0, 24)
+	for t := end.Add(-23 * time.Hour); !t.After(end); t = t.Add(time.Hour) {
+		history = append(history, intensity(zone, t, HistoryIntensity))
+	}
+
+	write(w, map[string]any{"zone": zone, "history": history})
+}
+
+// pastRange serves the hours between start and end, which are at most 10 days
+// apart.
+func (s *Server) pastRange(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	zone := query.Get("zone")
+
+	start, err := time.Parse(time.RFC3339, query.Get("start"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid start")
+		return
+	}
+
+	end, err := time.Parse(time.RFC3339, query.Get("end"))
+	if err != nil || end.Sub(start) > pastRangeMaxWindow {
+		writeError(w, http.StatusBadRequest, "Invalid end")
+		return
+	}
+
+	data := make([]map[string]any, 0)
+	for t := start.Truncate(time.Hour); t.Before(end); t = t.Add(time.Hour) {
+		if !t.Before(start) {
+			data = append(data, intensity(zone, t, PastRangeIntensity))
+		}
+	}
+
+	write(w, map[string]any{"zone": zone, "data": data})
+}
+
+func (s *Server) powerBreakdown(w http.ResponseWriter, r *http.Request) {
+	breakdown, ok := s.Breakdown[r.URL.Query().Get("zone")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Zone not found")
+		return
+	}
+
+	write(w, breakdown)
+}
+
+func intensity(zone string, t time.Time, value int) map[string]any {
+	return map[string]any{
+		"zone":               zone,
+		"carbonIntensity":    value,
+		"datetime":           t,
+		"updatedAt":          t.Add(time.Hour),
+		"emissionFactorType": "lifecycle",
+		"isEstimated":        false,
+	}
+}
+
+func write(w http.ResponseWriter, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(payload)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -11,13 +11,20 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"net/http"
 	"net/url"
-	"sigs.k8s.io/controller-runtime/pkg/client"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 )
 
 const (
 	electricityMapsBaseUrl      string = "https://api-access.electricitymaps.com/"
 	electricityMapsFreeTierPath string = "/free-tier"
+	forecastResolution                 = time.Hour
+	forecastHorizon                    = 24 * time.Hour
+	historyWindow                      = 24 * time.Hour
+	historyWindowTolerance             = 5 * time.Minute
+	pastRangeMaxWindow                 = 10 * 24 * time.Hour
 )
 
 type SubscriptionType string
@@ -38,38 +45,58 @@ func GetElectricityMapSubscriptionModels() []SubscriptionType {
 
 type ElectricityMapsProvider struct {
 	subscription            SubscriptionType
+	emissionFactorType      common.EmissionFactorType
 	apiKey                  string
 	baseUrl                 *url.URL
 	subscriptionRelativeUrl *url.URL
 	client                  *http.Client
 }
 
-func NewProvider(ctx context.Context, k client.Client, o carbonv1alpha1.ElectricityMaps) (*ElectricityMapsProvider, error) {
-	apiKeyRef := o.Spec.ApiKeyRef
-	if apiKeyRef.Namespace == "" {
-		apiKeyRef.Namespace = o.Namespace
-	}
-	objectKey := client.ObjectKey{
-		Namespace: apiKeyRef.Namespace,
-		Name:      apiKeyRef.Name,
-	}
-	secret := &corev1.Secret{}
-	if err := k.Get(ctx, objectKey, secret); err != nil {
-		return nil, err
-	}
-
+// NewProvider returns a provider for the ElectricityMaps object, authenticated
+// with the API key in the given Secret.
+func NewProvider(o carbonv1alpha1.ElectricityMaps, secret *corev1.Secret) (*ElectricityMapsProvider, error) {
 	apiKey := string(secret.Data["apiKey"])
 
+	var electricityMaps *ElectricityMapsProvider
+	var err error
+
 	switch o.Spec.Subscription {
 	case string(Commercial):
-		return newElectricityMapsCommercialProvider(apiKey)
+		electricityMaps, err = newElectricityMapsCommercialProvider(apiKey)
 	case string(CommercialTrial):
-		return newElectricityMapsCommercialTrialProvider(apiKey, o.Spec.CommercialTrialEndpoint)
+		electricityMaps, err = newElectricityMapsCommercialTrialProvider(apiKey, o.Spec.CommercialTrialEndpoint)
 	case string(FreeTier):
-		return newElectricityMapsFreeTierProvider(apiKey)
+		electricityMaps, err = newElectricityMapsFreeTierProvider(apiKey)
+	default:
+		return nil, fmt.Errorf("not supported ElectricityMaps subscription '%s'", o.Spec.Subscription)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	electricityMaps.emissionFactorType = common.EmissionFactorType(o.Spec.EmissionFactorType)
+
+	return electricityMaps, nil
+}
+
+// GetCapabilities returns the capabilities of ElectricityMaps; carbon
+// intensities are always averages, based on either lifecycle or direct
+// emission factors, and forecasts are not part of the free tier.
+func (p *ElectricityMapsProvider) GetCapabilities(ctx context.Context) (*common.Capabilities, error) {
+	capabilities := &common.Capabilities{
+		Forecast:        p.subscription != FreeTier,
+		ForecastHorizon: forecastHorizon,
+		Resolution:      forecastResolution,
+		EmissionsTypes:  []common.EmissionsType{common.Average},
+		ZoneNaming:      common.ElectricityMapsZone,
 	}
 
-	return nil, nil
+	if !capabilities.Forecast {
+		capabilities.ForecastHorizon = 0
+	}
+
+	return capabilities, nil
 }
 
 func newElectricityMapsCommercialProvider(apiKey string) (*ElectricityMapsProvider, error) {
@@ -135,98 +162,256 @@ func newElectricityMapsFreeTierProvider(apiKey string) (*ElectricityMapsProvider
 	return electricityMaps, nil
 }
 
-func (p *ElectricityMapsProvider) GetCurrent(ctx context.Context, zone string) (float64, error) {
-	requestUrl := common.ResolveAbsoluteUriReference(p.baseUrl, p.subscriptionRelativeUrl, &url.URL{Path: "/carbon-intensity/latest"})
-	params := url.Values{}
-	params.Add("zone", zone)
-	requestUrl.RawQuery = params.Encode()
-
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestUrl.String(), nil)
+func (p *ElectricityMapsProvider) GetCurrent(ctx context.Context, zone string) (*common.Reading, error) {
+	var result LiveResult
+	err := p.get(ctx, "/carbon-intensity/latest", p.zoneParams(zone), &result)
 	if err != nil {
-		return common.NoValue, err
+		return nil, err
 	}
 
-	request.Header.Add("auth-token", p.apiKey)
+	reading := &common.Reading{
+		Zone:               result.Zone,
+		Value:              float64(result.CarbonIntensity),
+		Unit:               common.GramsPerKilowattHour,
+		SignalType:         common.CarbonIntensity,
+		EmissionsType:      common.Average,
+		PointTime:          result.Datetime,
+		UpdatedAt:          result.UpdatedAt,
+		IsEstimated:        result.IsEstimated,
+		EstimationMethod:   result.EstimationMethod,
+		EmissionFactorType: common.EmissionFactorType(result.EmissionFactorType),
+	}
 
-	response, err := p.client.Do(request)
+	return reading, nil
+}
+
+func (p *ElectricityMapsProvider) GetForecast(ctx context.Context, zone string) (*common.Forecast, error) {
+	var result ForecastResult
+	err := p.get(ctx, "/carbon-intensity/forecast", p.zoneParams(zone), &result)
 	if err != nil {
-		return common.NoValue, err
+		return nil, err
 	}
-	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusOK {
-		apierr, msg, err := p.unwrapHttpResponseErrorPayload(response)
+	points := make([]common.ForecastPoint, 0, len(result.Forecast))
+	for _, f := range result.Forecast {
+		points = append(points, common.ForecastPoint{
+			PointTime: f.Datetime,
+			Value:     float64(f.CarbonIntensity),
+		})
+	}
+
+	forecast := common.NewForecast(
+		zone,
+		result.UpdatedAt,
+		common.GramsPerKilowattHour,
+		common.CarbonIntensity,
+		common.Average,
+		forecastResolution,
+		points,
+	)
+
+	return forecast, nil
+}
+
+// GetHistory returns the carbon intensities of the zone between start and end,
+// oldest first. Windows of at most 24 hours ending now are served by the
+// history endpoint, which free tier keys may call; any other window by the past
+// range endpoint, in windows of at most 10 days.
+func (p *ElectricityMapsProvider) GetHistory(ctx context.Context, zone string, start time.Time, end time.Time) ([]common.Reading, error) {
+	// a window ending now was computed a moment before this call
+	endsNow := !end.Before(time.Now().Add(-historyWindowTolerance))
+
+	var results []LiveResult
+	if endsNow && !start.Before(end.Add(-historyWindow)) {
+		var result HistoryResult
+		err := p.get(ctx, "/carbon-intensity/history", p.zoneParams(zone), &result)
 		if err != nil {
-			return common.NoValue, errors.New(response.Status)
+			return nil, err
 		}
 
-		return common.NoValue, errors.New(fmt.Sprintf("%s; %s: %s", response.Status, apierr, msg))
+		results = result.History
+	} else {
+		for from := start; from.Before(end); from = from.Add(pastRangeMaxWindow) {
+			to := from.Add(pastRangeMaxWindow)
+			if to.After(end) {
+				to = end
+			}
+
+			params := p.zoneParams(zone)
+			params.Add("start", from.UTC().Format(time.RFC3339))
+			params.Add("end", to.UTC().Format(time.RFC3339))
+
+			var result PastRangeResult
+			err := p.get(ctx, "/carbon-intensity/past-range", params, &result)
+			if err != nil {
+				return nil, err
+			}
+
+			results = append(results, result.Data...)
+		}
 	}
 
-	bytes, err := io.ReadAll(response.Body)
+	readings := make([]common.Reading, 0, len(results))
+	for _, result := range results {
+		if result.Datetime.Before(start) || result.Datetime.After(end) {
+			continue
+		}
+
+		readings = append(readings, common.Reading{
+			Zone:               zone,
+			Value:              float64(result.CarbonIntensity),
+			Unit:               common.GramsPerKilowattHour,
+			SignalType:         common.CarbonIntensity,
+			EmissionsType:      common.Average,
+			PointTime:          result.Datetime,
+			UpdatedAt:          result.UpdatedAt,
+			IsEstimated:        result.IsEstimated,
+			EstimationMethod:   result.EstimationMethod,
+			EmissionFactorType: common.EmissionFactorType(result.EmissionFactorType),
+		})
+	}
+
+	slices.SortFunc(readings, func(a, b common.Reading) int {
+		return a.PointTime.Compare(b.PointTime)
+	})
+
+	return readings, nil
+}
+
+// GetPowerBreakdown returns the latest production mix of the zone; sources
+// without a reported production are left out.
+func (p *ElectricityMapsProvider) GetPowerBreakdown(ctx context.Context, zone string) (*common.PowerBreakdown, error) {
+	params := url.Values{}
+	params.Add("zone", zone)
+
+	var result PowerBreakdownResult
+	err := p.get(ctx, "/power-breakdown/latest", params, &result)
 	if err != nil {
-		return common.NoValue, err
+		return nil, err
 	}
 
+	breakdown := &common.PowerBreakdown{
+		Zone:                 result.Zone,
+		PointTime:            result.Datetime,
+		UpdatedAt:            result.UpdatedAt,
+		RenewablePercentage:  result.RenewablePercentage,
+		FossilFreePercentage: result.FossilFreePercentage,
+		Production:           map[string]float64{},
+	}
+
+	for source, production := range result.PowerProductionBreakdown {
+		if production != nil {
+			breakdown.Production[source] = *production
+		}
+	}
+
+	if result.PowerProductionTotal != nil {
+		breakdown.ProductionTotal = *result.PowerProductionTotal
+	}
+
+	return breakdown, nil
+}
+
+// GetZones returns the zones the API key has access to.
+func (p *ElectricityMapsProvider) GetZones(ctx context.Context) ([]common.Zone, error) {
+	var result ZonesResult
+	err := p.get(ctx, "/zones", url.Values{}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	zones := make([]common.Zone, 0, len(result))
+	for name, zone := range result {
+		zones = append(zones, common.Zone{Name: name, DisplayName: zone.ZoneName})
+	}
+
+	slices.SortFunc(zones, func(a, b common.Zone) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return zones, nil
+}
+
+// GetZoneFromLocation returns the zone the given location lies in, as
+// reported by the latest carbon intensity of the location.
+func (p *ElectricityMapsProvider) GetZoneFromLocation(ctx context.Context, latitude float64, longitude float64) (*common.Zone, error) {
+	params := url.Values{}
+	params.Add("lat", strconv.FormatFloat(latitude, 'f', -1, 64))
+	params.Add("lon", strconv.FormatFloat(longitude, 'f', -1, 64))
+
 	var result LiveResult
-	err = json.Unmarshal(bytes, &result)
+	err := p.get(ctx, "/carbon-intensity/latest", params, &result)
 	if err != nil {
-		return common.NoValue, err
+		return nil, err
 	}
 
-	carbonIntensity := float64(result.CarbonIntensity)
-	return carbonIntensity, nil
+	if result.Zone == "" {
+		return nil, fmt.Errorf("no zone found for location %v,%v", latitude, longitude)
+	}
+
+	return &common.Zone{Name: result.Zone}, nil
+}
+
+// CheckCredentials verifies the API key by listing the zones it has access to.
+func (p *ElectricityMapsProvider) CheckCredentials(ctx context.Context) error {
+	var result ZonesResult
+	return p.get(ctx, "/zones", url.Values{}, &result)
 }
 
-func (p *ElectricityMapsProvider) GetForecast(ctx context.Context, zone string) (map[time.Time]float64, error) {
+func (p *ElectricityMapsProvider) zoneParams(zone string) url.Values {
+	params := url.Values{}
+	params.Add("zone", zone)
+	if p.emissionFactorType != "" {
+		params.Add("emissionFactorType", string(p.emissionFactorType))
+	}
+
+	return params
+}
+
+// get sends an authorized GET request to the given path of the ElectricityMaps
+// API and decodes the JSON response into result.
+func (p *ElectricityMapsProvider) get(ctx context.Context, path string, params url.Values, result any) error {
 	requestUrl := common.ResolveAbsoluteUriReference(
 		p.baseUrl,
 		p.subscriptionRelativeUrl,
-		&url.URL{Path: "/carbon-intensity/forecast"},
+		&url.URL{Path: path},
 	)
-	params := url.Values{}
-	params.Add("zone", zone)
 	requestUrl.RawQuery = params.Encode()
 
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestUrl.String(), nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	request.Header.Add("auth-token", p.apiKey)
 
 	response, err := p.client.Do(request)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
 		apierr, msg, err := p.unwrapHttpResponseErrorPayload(response)
 		if err != nil {
-			return nil, errors.New(response.Status)
+			err = errors.New(response.Status)
+		} else {
+			err = errors.New(fmt.Sprintf("%s; %s: %s", response.Status, apierr, msg))
 		}
 
-		return nil, errors.New(fmt.Sprintf("%s; %s: %s", response.Status, apierr, msg))
-	}
+		if response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden {
+			return fmt.Errorf("%w: %s", common.ErrInvalidCredentials, err)
+		}
 
-	bytes, err := io.ReadAll(response.Body)
-	if err != nil {
-		return nil, err
+		return err
 	}
 
-	var result ForecastResult
-	err = json.Unmarshal(bytes, &result)
+	bytes, err := io.ReadAll(response.Body)
 	if err != nil {
-		return nil, err
-	}
-
-	forecasts := make(map[time.Time]float64)
-	for _, f := range result.Forecast {
-		forecasts[f.Datetime] = float64(f.CarbonIntensity)
+		return err
 	}
 
-	return forecasts, nil
+	return json.Unmarshal(bytes, result)
 }
 
 func (p *ElectricityMapsProvider) unwrapHttpResponseErrorPayload(response *http.Response) (apiError string, message string, err error) {
@@ -1,10 +1,12 @@
 package providers
 
-type EmissionsType string
+import "github.com/rekuberate-io/carbon/pkg/common"
+
+type EmissionsType = common.EmissionsType
 
 const (
-	Average  EmissionsType = "average"
-	Marginal EmissionsType = "marginal"
+	Average  = common.Average
+	Marginal = common.Marginal
 )
 
 func GetSupportedEmissionsTypes() []EmissionsType {
@@ -0,0 +1,511 @@
+package entsoe
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	"github.com/rekuberate-io/carbon/pkg/common"
+	"github.com/rekuberate-io/carbon/pkg/emissionfactors"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	entsoeBaseUrl      = "https://web-api.tp.entsoe.eu/api"
+	securityTokenKey   = "securityToken"
+	periodLayout       = "200601021504"
+	resolution         = time.Hour
+	forecastHorizon    = 24 * time.Hour
+	actualWindow       = 24 * time.Hour
+	forecastWindow     = 48 * time.Hour
+	rateLimitRequests  = 400
+	rateLimitPeriod    = time.Minute
+	maxResponsePayload = 50 << 20
+
+	// actualGeneration is the document of the actual generation per
+	// production type
+	actualGeneration = "A75"
+	// windSolarForecast is the document of the day-ahead generation forecast
+	// of wind and solar
+	windSolarForecast = "A69"
+	// generationForecast is the document of the day-ahead forecast of the
+	// total generation
+	generationForecast = "A71"
+	processRealised    = "A16"
+	processDayAhead    = "A01"
+
+	// credentialsCheckArea is the area the security token is checked against
+	credentialsCheckArea = "10YNL----------L"
+)
+
+type ENTSOEProvider struct {
+	baseUrl         string
+	securityToken   string
+	factors         *emissionfactors.Set
+	emissionFactors map[string]float64
+	client          *http.Client
+}
+
+// NewProvider returns a provider for the ENTSOE object, authenticated with the
+// security token in the given Secret. Production types take the emission
+// factors of their fuels from the given set, or the default one if it is nil;
+// the emission factors of the object override them.
+func NewProvider(o carbonv1alpha1.ENTSOE, secret *corev1.Secret, factors *emissionfactors.Set) (*ENTSOEProvider, error) {
+	securityToken := string(secret.Data[securityTokenKey])
+	if securityToken == "" {
+		return nil, fmt.Errorf("key '%s' is missing in secret '%s'", securityTokenKey, secret.Name)
+	}
+
+	p := &ENTSOEProvider{
+		baseUrl:         entsoeBaseUrl,
+		securityToken:   securityToken,
+		factors:         factors,
+		emissionFactors: map[string]float64{},
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+
+	if o.Spec.Endpoint != nil && *o.Spec.Endpoint != "" {
+		p.baseUrl = *o.Spec.Endpoint
+	}
+
+	if p.factors == nil {
+		p.factors = emissionfactors.Default()
+	}
+
+	codes := map[string]string{}
+	for code, productionType := range productionTypes {
+		codes[productionType.name] = code
+	}
+
+	for name, value := range o.Spec.EmissionFactors {
+		code, ok := codes[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown production type '%s'", name)
+		}
+
+		emissionFactor, err := strconv.ParseFloat(string(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("emission factor of '%s': %w", name, err)
+		}
+
+		p.emissionFactors[code] = emissionFactor
+	}
+
+	return p, nil
+}
+
+// GetCapabilities returns the capabilities of the Transparency Platform;
+// carbon intensities are averages computed with the emission factors of the
+// production types, forecasts cover the day-ahead.
+func (p *ENTSOEProvider) GetCapabilities(ctx context.Context) (*common.Capabilities, error) {
+	return &common.Capabilities{
+		Forecast:        true,
+		ForecastHorizon: forecastHorizon,
+		Resolution:      resolution,
+		EmissionsTypes:  []common.EmissionsType{common.Average},
+		ZoneNaming:      common.ElectricityMapsZone,
+		RateLimit:       &common.RateLimit{Requests: rateLimitRequests, Period: rateLimitPeriod},
+	}, nil
+}
+
+// GetZones returns the zones with a known area; any other area is accepted by
+// its EIC code as well.
+func (p *ENTSOEProvider) GetZones(ctx context.Context) ([]common.Zone, error) {
+	zones := make([]common.Zone, 0, len(areas))
+	for zone := range areas {
+		zones = append(zones, common.Zone{Name: zone})
+	}
+
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].Name < zones[j].Name
+	})
+
+	return zones, nil
+}
+
+// IsValidZone reports whether the zone has a known area or is an EIC code.
+func (p *ENTSOEProvider) IsValidZone(ctx context.Context, zone string) (bool, error) {
+	_, ok := getArea(zone)
+	return ok, nil
+}
+
+// CheckCredentials verifies the security token by requesting a forecast,
+// requests with an invalid token are rejected regardless of their data.
+func (p *ENTSOEProvider) CheckCredentials(ctx context.Context) error {
+	from := time.Now().UTC().Truncate(time.Hour)
+	_, err := p.getSamples(ctx, generationForecast, processDayAhead, credentialsCheckArea, from, from.Add(time.Hour))
+
+	return err
+}
+
+// GetCurrent returns the carbon intensity of the latest interval all
+// production types reported their actual generation for.
+func (p *ENTSOEProvider) GetCurrent(ctx context.Context, zone string) (*common.Reading, error) {
+	area, ok := getArea(zone)
+	if !ok {
+		return nil, fmt.Errorf("unknown zone '%s'", zone)
+	}
+
+	mix, pointTime, err := p.getLatestMix(ctx, area)
+	if err != nil {
+		return nil, err
+	}
+
+	carbonIntensity, _ := p.intensity(area, mix.intervals[pointTime])
+	reading := &common.Reading{
+		Zone:               zone,
+		Value:              carbonIntensity,
+		Unit:               common.GramsPerKilowattHour,
+		SignalType:         common.CarbonIntensity,
+		EmissionsType:      common.Average,
+		PointTime:          pointTime,
+		EmissionFactorType: p.factors.Type(),
+		Frequency:          mix.resolution,
+	}
+
+	return reading, nil
+}
+
+// GetForecast returns the carbon intensity of the day-ahead generation. Only
+// wind and solar are forecast per production type; the rest of the forecast
+// total generation is split by the shares the other production types had in
+// the latest actual generation.
+func (p *ENTSOEProvider) GetForecast(ctx context.Context, zone string) (*common.Forecast, error) {
+	area, ok := getArea(zone)
+	if !ok {
+		return nil, fmt.Errorf("unknown zone '%s'", zone)
+	}
+
+	now := time.Now()
+	from := now.UTC().Truncate(time.Hour)
+	to := from.Add(forecastWindow)
+
+	total, err := p.getSamples(ctx, generationForecast, processDayAhead, area, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	windSolar, err := p.getSamples(ctx, windSolarForecast, processDayAhead, area, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// without an actual generation the rest is attributed to other sources
+	shares := map[string]float64{otherProductionType: 1}
+	if mix, pointTime, err := p.getLatestMix(ctx, area); err == nil {
+		if latest := getShares(mix.intervals[pointTime], forecastProductionTypes); len(latest) > 0 {
+			shares = latest
+		}
+	}
+
+	for i := range total {
+		total[i].productionType = totalProductionType
+	}
+
+	mix := newGenerationMix(append(total, windSolar...))
+	points := make([]common.ForecastPoint, 0, len(mix.intervals))
+	for _, t := range mix.times() {
+		interval := mix.intervals[t]
+		totalPower, ok := interval[totalProductionType]
+		if !ok || t.Before(from) {
+			continue
+		}
+
+		generation := map[string]float64{}
+		rest := totalPower
+		for _, code := range forecastProductionTypes {
+			if power, ok := interval[code]; ok {
+				generation[code] = power
+				rest -= power
+			}
+		}
+
+		if rest > 0 {
+			for code, share := range shares {
+				generation[code] += rest * share
+			}
+		}
+
+		if carbonIntensity, ok := p.intensity(area, generation); ok {
+			points = append(points, common.ForecastPoint{PointTime: t, Value: carbonIntensity})
+		}
+	}
+
+	if len(points) == 0 {
+		return nil, fmt.Errorf("no day-ahead generation forecast of zone '%s' was published", zone)
+	}
+
+	forecast := common.NewForecast(
+		zone,
+		now,
+		common.GramsPerKilowattHour,
+		common.CarbonIntensity,
+		common.Average,
+		mix.resolution,
+		points,
+	)
+
+	return forecast, nil
+}
+
+// GetHistory returns the carbon intensity of every interval between start and
+// end that all production types reported their actual generation for.
+func (p *ENTSOEProvider) GetHistory(ctx context.Context, zone string, start time.Time, end time.Time) ([]common.Reading, error) {
+	area, ok := getArea(zone)
+	if !ok {
+		return nil, fmt.Errorf("unknown zone '%s'", zone)
+	}
+
+	samples, err := p.getSamples(ctx, actualGeneration, processRealised, area, start.UTC().Truncate(time.Hour), end)
+	if err != nil {
+		return nil, err
+	}
+
+	mix := newGenerationMix(samples)
+	readings := make([]common.Reading, 0, len(mix.intervals))
+	for _, t := range mix.completeTimes() {
+		if t.Before(start) || t.After(end) {
+			continue
+		}
+
+		carbonIntensity, ok := p.intensity(area, mix.intervals[t])
+		if !ok {
+			continue
+		}
+
+		readings = append(readings, common.Reading{
+			Zone:               zone,
+			Value:              carbonIntensity,
+			Unit:               common.GramsPerKilowattHour,
+			SignalType:         common.CarbonIntensity,
+			EmissionsType:      common.Average,
+			PointTime:          t,
+			EmissionFactorType: p.factors.Type(),
+			Frequency:          mix.resolution,
+		})
+	}
+
+	return readings, nil
+}
+
+// GetPowerBreakdown returns the actual generation per production type of the
+// latest interval all production types reported for.
+func (p *ENTSOEProvider) GetPowerBreakdown(ctx context.Context, zone string) (*common.PowerBreakdown, error) {
+	area, ok := getArea(zone)
+	if !ok {
+		return nil, fmt.Errorf("unknown zone '%s'", zone)
+	}
+
+	mix, pointTime, err := p.getLatestMix(ctx, area)
+	if err != nil {
+		return nil, err
+	}
+
+	breakdown := &common.PowerBreakdown{
+		Zone:       zone,
+		PointTime:  pointTime,
+		Production: map[string]float64{},
+	}
+
+	var renewable, fossilFree float64
+	for code, power := range mix.intervals[pointTime] {
+		if power <= 0 {
+			continue
+		}
+
+		productionType, ok := productionTypes[code]
+		if !ok {
+			productionType = productionTypes[otherProductionType]
+		}
+
+		breakdown.Production[productionType.name] += power
+		breakdown.ProductionTotal += power
+		if productionType.renewable {
+			renewable += power
+		}
+		if productionType.fossilFree {
+			fossilFree += power
+		}
+	}
+
+	if breakdown.ProductionTotal > 0 {
+		renewablePercentage := 100 * renewable / breakdown.ProductionTotal
+		fossilFreePercentage := 100 * fossilFree / breakdown.ProductionTotal
+		breakdown.RenewablePercentage = &renewablePercentage
+		breakdown.FossilFreePercentage = &fossilFreePercentage
+	}
+
+	return breakdown, nil
+}
+
+// getLatestMix returns the actual generation of the area in the last day along
+// with the start of its latest complete interval.
+func (p *ENTSOEProvider) getLatestMix(ctx context.Context, area string) (*generationMix, time.Time, error) {
+	to := time.Now().UTC().Truncate(time.Hour).Add(time.Hour)
+	samples, err := p.getSamples(ctx, actualGeneration, processRealised, area, to.Add(-actualWindow), to)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	mix := newGenerationMix(samples)
+	times := mix.completeTimes()
+	for i := len(times) - 1; i >= 0; i-- {
+		if _, ok := p.intensity(area, mix.intervals[times[i]]); ok {
+			return mix, times[i], nil
+		}
+	}
+
+	return nil, time.Time{}, fmt.Errorf("no actual generation of area '%s' was published within the last %s", area, actualWindow)
+}
+
+// intensity returns the carbon intensity of the generation of an area in an
+// interval, or false if nothing was generated.
+func (p *ENTSOEProvider) intensity(area string, generation map[string]float64) (float64, bool) {
+	var total, emissions float64
+	for code, power := range generation {
+		if code == totalProductionType || power <= 0 {
+			continue
+		}
+
+		total += power
+		emissions += power * p.emissionFactor(area, code)
+	}
+
+	if total == 0 {
+		return 0, false
+	}
+
+	return emissions / total, true
+}
+
+// emissionFactor returns the emission factor of a production type within an
+// area, which is the one of its fuel unless the object overrides it. Zone
+// overrides of the EmissionFactorSet are looked up by the EIC code of the area,
+// whether the issuer names it by its zone key or its EIC code.
+func (p *ENTSOEProvider) emissionFactor(area string, code string) float64 {
+	if emissionFactor, ok := p.emissionFactors[code]; ok {
+		return emissionFactor
+	}
+
+	productionType, ok := productionTypes[code]
+	if !ok {
+		productionType = productionTypes[otherProductionType]
+	}
+
+	return p.factors.Factor(area, productionType.fuel)
+}
+
+// getShares returns the share of each production type in the generation,
+// leaving out the excluded production types.
+func getShares(generation map[string]float64, excluded []string) map[string]float64 {
+	var total float64
+	for code, power := range generation {
+		if power > 0 && !slices.Contains(excluded, code) {
+			total += power
+		}
+	}
+
+	shares := map[string]float64{}
+	if total == 0 {
+		return shares
+	}
+
+	for code, power := range generation {
+		if power > 0 && !slices.Contains(excluded, code) {
+			shares[code] = power / total
+		}
+	}
+
+	return shares
+}
+
+// getSamples requests a document of the area between from and to and returns
+// its generation. Requests without any matching data return no samples.
+func (p *ENTSOEProvider) getSamples(ctx context.Context, documentType string, processType string, area string, from time.Time, to time.Time) ([]sample, error) {
+	params := url.Values{}
+	params.Set("documentType", documentType)
+	params.Set("processType", processType)
+	params.Set("in_Domain", area)
+	params.Set("periodStart", from.UTC().Format(periodLayout))
+	params.Set("periodEnd", to.UTC().Format(periodLayout))
+
+	document, err := p.get(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return document.samples()
+}
+
+func (p *ENTSOEProvider) get(ctx context.Context, params url.Values) (*MarketDocument, error) {
+	requestUrl, err := url.Parse(p.baseUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	params.Set(securityTokenKey, p.securityToken)
+	requestUrl.RawQuery = params.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Accept", "application/xml")
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("%w: %s", common.ErrInvalidCredentials, response.Status)
+	}
+
+	bytes, err := io.ReadAll(io.LimitReader(response.Body, maxResponsePayload))
+	if err != nil {
+		return nil, err
+	}
+
+	// failed requests are answered with an acknowledgement document, which
+	// also reports requests without any matching data
+	document := &MarketDocument{}
+	if err := xml.Unmarshal(bytes, document); err != nil {
+		if response.StatusCode != http.StatusOK {
+			return nil, errors.New(response.Status)
+		}
+
+		return nil, err
+	}
+
+	if document.XMLName.Local == acknowledgementDocument {
+		reasons := make([]string, 0, len(document.Reasons))
+		for _, reason := range document.Reasons {
+			if reason.Code == noMatchingData {
+				return &MarketDocument{}, nil
+			}
+
+			reasons = append(reasons, fmt.Sprintf("%s (%s)", reason.Text, reason.Code))
+		}
+
+		return nil, fmt.Errorf("request was rejected: %s", strings.Join(reasons, ", "))
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.New(response.Status)
+	}
+
+	return document, nil
+}
@@ -0,0 +1,249 @@
+package entsoe
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	// acknowledgementDocument is the root element of error responses
+	acknowledgementDocument = "Acknowledgement_MarketDocument"
+	// noMatchingData is the reason code of responses without any data
+	noMatchingData = "999"
+	// variableSizedBlocks is the curve type of time series that omit points
+	// whose quantity equals the one of the previous point
+	variableSizedBlocks = "A03"
+	// totalProductionType is the production type time series of the total
+	// generation are collected under
+	totalProductionType = ""
+)
+
+var (
+	// timeIntervalLayouts are the layouts of the boundaries of periods, which
+	// are given in minutes, e.g. 2024-03-01T00:00Z
+	timeIntervalLayouts = []string{"2006-01-02T15:04Z07:00", time.RFC3339}
+	resolutionPattern   = regexp.MustCompile(`^PT(?:([0-9]+)H)?(?:([0-9]+)M)?$`)
+)
+
+// MarketDocument is a GL_MarketDocument of generation data, or an
+// Acknowledgement_MarketDocument if the request failed.
+type MarketDocument struct {
+	XMLName    xml.Name
+	TimeSeries []TimeSeries `xml:"TimeSeries"`
+	Reasons    []Reason     `xml:"Reason"`
+}
+
+type Reason struct {
+	Code string `xml:"code"`
+	Text string `xml:"text"`
+}
+
+type TimeSeries struct {
+	InDomain  string   `xml:"inBiddingZone_Domain.mRID"`
+	OutDomain string   `xml:"outBiddingZone_Domain.mRID"`
+	CurveType string   `xml:"curveType"`
+	PsrType   string   `xml:"MktPSRType>psrType"`
+	Periods   []Period `xml:"Period"`
+}
+
+type Period struct {
+	Start      string  `xml:"timeInterval>start"`
+	End        string  `xml:"timeInterval>end"`
+	Resolution string  `xml:"resolution"`
+	Points     []Point `xml:"Point"`
+}
+
+type Point struct {
+	Position int     `xml:"position"`
+	Quantity float64 `xml:"quantity"`
+}
+
+// sample is the power generated by a production type within an interval, in
+// MW.
+type sample struct {
+	productionType string
+	start          time.Time
+	length         time.Duration
+	power          float64
+}
+
+// generationMix is the power generated per production type, in MW, for each
+// interval of a common resolution.
+type generationMix struct {
+	resolution time.Duration
+	intervals  map[time.Time]map[string]float64
+}
+
+// samples returns the generation of the time series of the document. Time
+// series of consumption, e.g. of pumped storage, are skipped.
+func (d *MarketDocument) samples() ([]sample, error) {
+	samples := make([]sample, 0)
+	for _, ts := range d.TimeSeries {
+		if ts.InDomain == "" && ts.OutDomain != "" {
+			continue
+		}
+
+		for _, period := range ts.Periods {
+			periodSamples, err := period.samples(ts.PsrType, ts.CurveType == variableSizedBlocks)
+			if err != nil {
+				return nil, err
+			}
+
+			samples = append(samples, periodSamples...)
+		}
+	}
+
+	return samples, nil
+}
+
+// samples returns a sample per point of the period. Points of variable sized
+// blocks last until the next point, or the end of the period.
+func (p *Period) samples(productionType string, variableSized bool) ([]sample, error) {
+	start, err := parseTimeInterval(p.Start)
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := parseTimeInterval(p.End)
+	if err != nil {
+		return nil, err
+	}
+
+	resolution, err := parseResolution(p.Resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]Point, len(p.Points))
+	copy(points, p.Points)
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Position < points[j].Position
+	})
+
+	samples := make([]sample, 0, len(points))
+	for i, point := range points {
+		pointStart := start.Add(time.Duration(point.Position-1) * resolution)
+		pointEnd := pointStart.Add(resolution)
+		if variableSized {
+			pointEnd = end
+			if i+1 < len(points) {
+				pointEnd = start.Add(time.Duration(points[i+1].Position-1) * resolution)
+			}
+		}
+
+		if !pointStart.Before(end) {
+			break
+		}
+
+		samples = append(samples, sample{
+			productionType: productionType,
+			start:          pointStart,
+			length:         pointEnd.Sub(pointStart),
+			power:          point.Quantity,
+		})
+	}
+
+	return samples, nil
+}
+
+// newGenerationMix spreads the samples over intervals of the finest resolution
+// among them, so that production types of different resolutions add up.
+func newGenerationMix(samples []sample) *generationMix {
+	mix := &generationMix{intervals: map[time.Time]map[string]float64{}}
+	for _, s := range samples {
+		if mix.resolution == 0 || s.length < mix.resolution {
+			mix.resolution = s.length
+		}
+	}
+
+	for _, s := range samples {
+		for t := s.start; t.Before(s.start.Add(s.length)); t = t.Add(mix.resolution) {
+			interval, ok := mix.intervals[t]
+			if !ok {
+				interval = map[string]float64{}
+				mix.intervals[t] = interval
+			}
+
+			interval[s.productionType] += s.power
+		}
+	}
+
+	return mix
+}
+
+// times returns the sorted start times of the intervals.
+func (m *generationMix) times() []time.Time {
+	times := make([]time.Time, 0, len(m.intervals))
+	for t := range m.intervals {
+		times = append(times, t)
+	}
+
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+
+	return times
+}
+
+// completeTimes returns the sorted start times of the intervals that all
+// production types of the mix reported for. Generation is published with a
+// delay that differs by production type, so the latest intervals are often
+// incomplete.
+func (m *generationMix) completeTimes() []time.Time {
+	reported := map[string]bool{}
+	for _, interval := range m.intervals {
+		for productionType := range interval {
+			reported[productionType] = true
+		}
+	}
+
+	times := make([]time.Time, 0, len(m.intervals))
+	for _, t := range m.times() {
+		if len(m.intervals[t]) == len(reported) {
+			times = append(times, t)
+		}
+	}
+
+	return times
+}
+
+func parseTimeInterval(s string) (time.Time, error) {
+	var err error
+	for _, layout := range timeIntervalLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, err
+}
+
+// parseResolution parses the ISO 8601 durations of periods, e.g. PT15M.
+func parseResolution(s string) (time.Duration, error) {
+	match := resolutionPattern.FindStringSubmatch(s)
+	if match == nil || (match[1] == "" && match[2] == "") {
+		return 0, fmt.Errorf("not supported resolution '%s'", s)
+	}
+
+	var resolution time.Duration
+	if match[1] != "" {
+		hours, _ := strconv.Atoi(match[1])
+		resolution += time.Duration(hours) * time.Hour
+	}
+
+	if match[2] != "" {
+		minutes, _ := strconv.Atoi(match[2])
+		resolution += time.Duration(minutes) * time.Minute
+	}
+
+	if resolution <= 0 {
+		return 0, fmt.Errorf("not supported resolution '%s'", s)
+	}
+
+	return resolution, nil
+}
@@ -0,0 +1,149 @@
+package entsoe_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	"github.com/rekuberate-io/carbon/pkg/common"
+	"github.com/rekuberate-io/carbon/pkg/emissionfactors"
+	"github.com/rekuberate-io/carbon/pkg/providers/entsoe"
+	"github.com/rekuberate-io/carbon/pkg/providers/entsoe/entsoetest"
+	"github.com/rekuberate-io/carbon/pkg/providers/providertest"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const securityToken = "4c1d7e2a-93b8-4f6e-a0d5-6b2c8e1f7a39"
+
+// newTestProvider returns a provider of an ENTSOE object with the given spec
+// and security token, and the emission factors of the set. It reads from a
+// stand-in that serves the documents recorded for the Netherlands, where in
+// the hour before the latest gas, hard coal, nuclear, solar and onshore wind
+// generated 4000, 1000, 500, 1000 and 2000 MW.
+func newTestProvider(t *testing.T, token string, spec carbonv1alpha1.ENTSOESpec, factors *emissionfactors.Set) *entsoe.ENTSOEProvider {
+	server := entsoetest.NewServer(securityToken)
+	t.Cleanup(server.Close)
+
+	endpoint := server.URL + "/api"
+	spec.Endpoint = &endpoint
+	secret := &corev1.Secret{Data: map[string][]byte{"securityToken": []byte(token)}}
+
+	provider, err := entsoe.NewProvider(carbonv1alpha1.ENTSOE{Spec: spec}, secret, factors)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return provider
+}
+
+func TestGetCurrent(t *testing.T) {
+	provider := newTestProvider(t, securityToken, carbonv1alpha1.ENTSOESpec{}, nil)
+
+	reading, err := provider.GetCurrent(context.Background(), "NL")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the latest hour lacks wind, so the reading is of the hour before:
+	// (4000*490 + 1000*820 + 500*12 + 1000*45 + 2000*11) / 8500, leaving out
+	// the consumption of the area
+	if !providertest.Near(reading.Value, 335.65) {
+		t.Errorf("reading = %v, want 335.65", reading.Value)
+	}
+
+	if reading.Frequency.Minutes() != 15 {
+		t.Errorf("frequency = %s, want the 15m of solar", reading.Frequency)
+	}
+
+	if _, err := provider.GetCurrent(context.Background(), "DE"); err == nil {
+		t.Error("expected an error for a zone without any published generation")
+	}
+}
+
+func TestGetForecast(t *testing.T) {
+	provider := newTestProvider(t, securityToken, carbonv1alpha1.ENTSOESpec{}, nil)
+
+	forecast, err := provider.GetForecast(context.Background(), "NL")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(forecast.Points) != 48 {
+		t.Fatalf("forecast has %d points, want 48", len(forecast.Points))
+	}
+
+	// wind and solar as forecast, the remaining 5000 split by the actual
+	// shares of gas, hard coal and nuclear
+	for _, point := range forecast.Points {
+		if !providertest.Near(point.Value, 291.97) {
+			t.Errorf("point %s = %v, want 291.97", point.PointTime, point.Value)
+		}
+	}
+}
+
+func TestGetPowerBreakdown(t *testing.T) {
+	provider := newTestProvider(t, securityToken, carbonv1alpha1.ENTSOESpec{}, nil)
+
+	breakdown, err := provider.GetPowerBreakdown(context.Background(), "NL")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if breakdown.ProductionTotal != 8500 || breakdown.Production["windOnshore"] != 2000 || breakdown.Production["hardCoal"] != 1000 {
+		t.Errorf("production = %v, want 8500 in total", breakdown.Production)
+	}
+
+	if breakdown.RenewablePercentage == nil || !providertest.Near(*breakdown.RenewablePercentage, 3000.0/85) {
+		t.Errorf("renewable percentage = %v, want %v", breakdown.RenewablePercentage, 3000.0/85)
+	}
+}
+
+func TestEmissionFactors(t *testing.T) {
+	provider := newTestProvider(t, securityToken, carbonv1alpha1.ENTSOESpec{
+		EmissionFactors: map[string]carbonv1alpha1.EmissionFactor{"gas": "400"},
+	}, nil)
+
+	reading, err := provider.GetCurrent(context.Background(), "NL")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 360000 less than with the default factor of gas
+	if !providertest.Near(reading.Value, 293.29) {
+		t.Errorf("reading = %v, want 293.29", reading.Value)
+	}
+}
+
+func TestInvalidSecurityToken(t *testing.T) {
+	provider := newTestProvider(t, "invalid", carbonv1alpha1.ENTSOESpec{}, nil)
+
+	if err := provider.CheckCredentials(context.Background()); !errors.Is(err, common.ErrInvalidCredentials) {
+		t.Errorf("err = %v, want %v", err, common.ErrInvalidCredentials)
+	}
+}
+
+func TestEmissionFactorSet(t *testing.T) {
+	set, err := emissionfactors.NewSet(&carbonv1alpha1.EmissionFactorSet{Spec: carbonv1alpha1.EmissionFactorSetSpec{
+		Type:  carbonv1alpha1.Direct,
+		Zones: []carbonv1alpha1.ZoneEmissionFactors{{Zone: entsoetest.Area, Factors: map[string]carbonv1alpha1.EmissionFactor{"gas": "400"}}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider := newTestProvider(t, securityToken, carbonv1alpha1.ENTSOESpec{}, set)
+
+	// only gas and hard coal emit directly, gas at 400 within the area whether
+	// it is named by its zone key or its EIC code: (4000*400 + 1000*760) / 8500
+	for _, zone := range []string{"NL", entsoetest.Area} {
+		reading, err := provider.GetCurrent(context.Background(), zone)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !providertest.Near(reading.Value, 277.65) || reading.EmissionFactorType != common.Direct {
+			t.Errorf("%s: reading = %v %s, want 277.65 %s", zone, reading.Value, reading.EmissionFactorType, common.Direct)
+		}
+	}
+}
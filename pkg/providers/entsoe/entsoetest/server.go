@@ -0,0 +1,86 @@
+// Package entsoetest provides a stand-in for the API of the ENTSO-E
+// Transparency Platform, serving recorded documents for tests that must not
+// depend on the platform.
+package entsoetest
+
+import (
+	"embed"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"time"
+)
+
+const (
+	// Area is the area the recorded documents were published for
+	Area = "10YNL----------L"
+
+	periodLayout   = "200601021504"
+	intervalLayout = "2006-01-02T15:04Z"
+)
+
+// recordedStart is the start of the period all documents were recorded for
+var recordedStart = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+var intervalPattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}Z`)
+
+//go:embed documents/*.xml
+var documents embed.FS
+
+// recorded maps a document and process type to the document recorded for it
+var recorded = map[[2]string]string{
+	{"A75", "A16"}: "documents/actual_generation.xml",
+	{"A71", "A01"}: "documents/generation_forecast.xml",
+	{"A69", "A01"}: "documents/wind_solar_forecast.xml",
+}
+
+// NewServer starts a stand-in of the API accepting the given security token.
+// It serves the recorded documents of Area moved to the requested period, and
+// answers any other request with no matching data. The caller must close the
+// server.
+func NewServer(securityToken string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("securityToken") != securityToken {
+			serve(w, http.StatusUnauthorized, "documents/unauthorized.xml", 0)
+			return
+		}
+
+		periodStart, err := time.Parse(periodLayout, query.Get("periodStart"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		name, ok := recorded[[2]string{query.Get("documentType"), query.Get("processType")}]
+		if !ok || query.Get("in_Domain") != Area {
+			serve(w, http.StatusOK, "documents/no_matching_data.xml", 0)
+			return
+		}
+
+		serve(w, http.StatusOK, name, periodStart.Sub(recordedStart))
+	}))
+}
+
+// serve writes the recorded document with its time intervals shifted by the
+// given offset.
+func serve(w http.ResponseWriter, status int, name string, offset time.Duration) {
+	document, err := documents.ReadFile(name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	document = intervalPattern.ReplaceAllFunc(document, func(b []byte) []byte {
+		t, err := time.Parse(intervalLayout, string(b))
+		if err != nil {
+			return b
+		}
+
+		return []byte(t.Add(offset).Format(intervalLayout))
+	})
+
+	w.Header().Set("Content-Type", "text/xml")
+	w.WriteHeader(status)
+	_, _ = w.Write(document)
+}
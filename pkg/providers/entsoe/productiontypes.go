@@ -0,0 +1,48 @@
+package entsoe
+
+import (
+	"github.com/rekuberate-io/carbon/pkg/emissionfactors"
+)
+
+// productionType is a production type (psrType) of the Transparency Platform
+// along with the fuel it takes its emission factor from.
+type productionType struct {
+	name       string
+	fuel       emissionfactors.Fuel
+	renewable  bool
+	fossilFree bool
+}
+
+// otherProductionType is the code of the production type that generation of
+// unknown production types is attributed to
+const otherProductionType = "B20"
+
+// productionTypes maps the psrType codes to production types. Coal-like fuels
+// count as coal, oil-like fuels as oil, and pumped storage as storage.
+var productionTypes = map[string]productionType{
+	"B01": {name: "biomass", fuel: emissionfactors.Biomass, renewable: true, fossilFree: true},
+	"B02": {name: "lignite", fuel: emissionfactors.Lignite},
+	"B03": {name: "coalDerivedGas", fuel: emissionfactors.Coal},
+	"B04": {name: "gas", fuel: emissionfactors.Gas},
+	"B05": {name: "hardCoal", fuel: emissionfactors.Coal},
+	"B06": {name: "oil", fuel: emissionfactors.Oil},
+	"B07": {name: "oilShale", fuel: emissionfactors.Oil},
+	"B08": {name: "peat", fuel: emissionfactors.Peat},
+	"B09": {name: "geothermal", fuel: emissionfactors.Geothermal, renewable: true, fossilFree: true},
+	"B10": {name: "hydroPumpedStorage", fuel: emissionfactors.Storage, fossilFree: true},
+	"B11": {name: "hydroRunOfRiver", fuel: emissionfactors.Hydro, renewable: true, fossilFree: true},
+	"B12": {name: "hydroReservoir", fuel: emissionfactors.Hydro, renewable: true, fossilFree: true},
+	"B13": {name: "marine", fuel: emissionfactors.Marine, renewable: true, fossilFree: true},
+	"B14": {name: "nuclear", fuel: emissionfactors.Nuclear, fossilFree: true},
+	"B15": {name: "otherRenewable", fuel: emissionfactors.OtherRenewable, renewable: true, fossilFree: true},
+	"B16": {name: "solar", fuel: emissionfactors.Solar, renewable: true, fossilFree: true},
+	"B17": {name: "waste", fuel: emissionfactors.Waste},
+	"B18": {name: "windOffshore", fuel: emissionfactors.Wind, renewable: true, fossilFree: true},
+	"B19": {name: "windOnshore", fuel: emissionfactors.Wind, renewable: true, fossilFree: true},
+	"B20": {name: "other", fuel: emissionfactors.Other},
+	"B25": {name: "energyStorage", fuel: emissionfactors.Storage, fossilFree: true},
+}
+
+// forecastProductionTypes are the production types the day-ahead forecast of
+// wind and solar generation covers
+var forecastProductionTypes = []string{"B16", "B18", "B19"}
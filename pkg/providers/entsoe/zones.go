@@ -0,0 +1,68 @@
+package entsoe
+
+import (
+	"regexp"
+)
+
+// eicPattern matches the EIC codes of areas, e.g. 10YNL----------L
+var eicPattern = regexp.MustCompile(`^[0-9]{2}Y[A-Z0-9-]{13}$`)
+
+// areas maps the zone keys, as ElectricityMaps names them, to the EIC codes of
+// the bidding zones or control areas the Transparency Platform publishes the
+// generation of.
+var areas = map[string]string{
+	"AT":     "10YAT-APG------L",
+	"BE":     "10YBE----------2",
+	"BG":     "10YCA-BULGARIA-R",
+	"CH":     "10YCH-SWISSGRIDZ",
+	"CZ":     "10YCZ-CEPS-----N",
+	"DE":     "10Y1001A1001A83F",
+	"DK-DK1": "10YDK-1--------W",
+	"DK-DK2": "10YDK-2--------M",
+	"EE":     "10Y1001A1001A39I",
+	"ES":     "10YES-REE------0",
+	"FI":     "10YFI-1--------U",
+	"FR":     "10YFR-RTE------C",
+	"GR":     "10YGR-HTSO-----Y",
+	"HR":     "10YHR-HEP------M",
+	"HU":     "10YHU-MAVIR----U",
+	"IE":     "10YIE-1001A00010",
+	"IT-CNO": "10Y1001A1001A70O",
+	"IT-CSO": "10Y1001A1001A71M",
+	"IT-NO":  "10Y1001A1001A73I",
+	"IT-SAR": "10Y1001A1001A74G",
+	"IT-SIC": "10Y1001A1001A75E",
+	"IT-SO":  "10Y1001A1001A788",
+	"LT":     "10YLT-1001A0008Q",
+	"LV":     "10YLV-1001A00074",
+	"NL":     "10YNL----------L",
+	"NO-NO1": "10YNO-1--------2",
+	"NO-NO2": "10YNO-2--------T",
+	"NO-NO3": "10YNO-3--------J",
+	"NO-NO4": "10YNO-4--------9",
+	"NO-NO5": "10Y1001A1001A48H",
+	"PL":     "10YPL-AREA-----S",
+	"PT":     "10YPT-REN------W",
+	"RO":     "10YRO-TEL------P",
+	"RS":     "10YCS-SERBIATSOV",
+	"SE-SE1": "10Y1001A1001A44P",
+	"SE-SE2": "10Y1001A1001A45N",
+	"SE-SE3": "10Y1001A1001A46L",
+	"SE-SE4": "10Y1001A1001A47J",
+	"SI":     "10YSI-ELES-----O",
+	"SK":     "10YSK-SEPS-----K",
+}
+
+// getArea returns the EIC code of a zone, which is either a zone key or an EIC
+// code itself.
+func getArea(zone string) (string, bool) {
+	if area, ok := areas[zone]; ok {
+		return area, true
+	}
+
+	if eicPattern.MatchString(zone) {
+		return zone, true
+	}
+
+	return "", false
+}
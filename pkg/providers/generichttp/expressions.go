@@ -0,0 +1,227 @@
+package generichttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/cel-go/cel"
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/client-go/util/jsonpath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// selfVariable is the name of the document in CEL expressions, as in the CEL
+// validation rules of Kubernetes
+const selfVariable = "self"
+
+// expression extracts a value from a decoded JSON document.
+type expression interface {
+	evaluate(document any) (any, error)
+	// evaluateArray extracts an array, which may be empty or hold a single
+	// element
+	evaluateArray(document any) ([]any, error)
+}
+
+// CompileExpression compiles a JSONPath or CEL expression, so that it can be
+// validated before the provider is used.
+func CompileExpression(e carbonv1alpha1.GenericHTTPExpression) error {
+	_, err := compileExpression(e)
+	return err
+}
+
+func compileExpression(e carbonv1alpha1.GenericHTTPExpression) (expression, error) {
+	switch {
+	case e.JSONPath != "" && e.CEL != "":
+		return nil, errors.New("exactly one of jsonPath or cel must be set")
+	case e.JSONPath != "":
+		return compileJSONPath(e.JSONPath)
+	case e.CEL != "":
+		return compileCEL(e.CEL)
+	default:
+		return nil, errors.New("exactly one of jsonPath or cel must be set")
+	}
+}
+
+type jsonPathExpression struct {
+	path *jsonpath.JSONPath
+}
+
+// compileJSONPath parses a JSONPath expression in the syntax of kubectl, the
+// enclosing braces are optional.
+func compileJSONPath(expr string) (*jsonPathExpression, error) {
+	if !strings.HasPrefix(expr, "{") {
+		expr = fmt.Sprintf("{%s}", expr)
+	}
+
+	path := jsonpath.New("expression")
+	if err := path.Parse(expr); err != nil {
+		return nil, fmt.Errorf("invalid jsonPath: %w", err)
+	}
+
+	return &jsonPathExpression{path: path}, nil
+}
+
+// evaluate returns the single result of the expression, or all of its results
+// as an array if there are several, e.g. for {.data[*]}.
+func (e *jsonPathExpression) evaluate(document any) (any, error) {
+	values, err := e.matches(document)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(values) {
+	case 0:
+		return nil, errors.New("jsonPath did not match")
+	case 1:
+		return values[0], nil
+	default:
+		return values, nil
+	}
+}
+
+// evaluateArray returns the array the expression selects, e.g. {.data}, or
+// the elements it matches, e.g. {.data[*]}, which may be none or only one.
+func (e *jsonPathExpression) evaluateArray(document any) ([]any, error) {
+	values, err := e.matches(document)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) == 1 {
+		if array, ok := values[0].([]any); ok {
+			return array, nil
+		}
+	}
+
+	return values, nil
+}
+
+// matches returns all results of the expression.
+func (e *jsonPathExpression) matches(document any) ([]any, error) {
+	results, err := e.path.FindResults(document)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]any, 0)
+	for _, result := range results {
+		for _, value := range result {
+			if value.Kind() == reflect.Interface && value.IsNil() {
+				values = append(values, nil)
+				continue
+			}
+
+			values = append(values, value.Interface())
+		}
+	}
+
+	return values, nil
+}
+
+type celExpression struct {
+	program cel.Program
+}
+
+func compileCEL(expr string) (*celExpression, error) {
+	env, err := cel.NewEnv(cel.Variable(selfVariable, cel.DynType))
+	if err != nil {
+		return nil, err
+	}
+
+	ast, issues := env.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("invalid cel: %w", issues.Err())
+	}
+
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cel: %w", err)
+	}
+
+	return &celExpression{program: program}, nil
+}
+
+// evaluate returns the result of the expression as a JSON value, so that the
+// results of CEL and JSONPath expressions are converted alike.
+func (e *celExpression) evaluate(document any) (any, error) {
+	value, _, err := e.program.Eval(map[string]any{selfVariable: document})
+	if err != nil {
+		return nil, err
+	}
+
+	native, err := value.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return native.(*structpb.Value).AsInterface(), nil
+}
+
+func (e *celExpression) evaluateArray(document any) ([]any, error) {
+	value, err := e.evaluate(document)
+	if err != nil {
+		return nil, err
+	}
+
+	return toArray(value)
+}
+
+// toFloat converts an extracted value to a number; numbers in strings are
+// accepted as well.
+func toFloat(value any) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int64:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(v), 64)
+	default:
+		return 0, fmt.Errorf("value %v (%T) is not a number", value, value)
+	}
+}
+
+// toTime converts an extracted value to a time, either from RFC 3339 or from
+// seconds since the epoch. Epochs beyond the year 33658 are taken as
+// milliseconds.
+func toTime(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, nil
+		}
+	}
+
+	epoch, err := toFloat(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("timestamp %v (%T) is neither RFC 3339 nor seconds since the epoch", value, value)
+	}
+
+	if epoch > 1e12 {
+		return time.UnixMilli(int64(epoch)), nil
+	}
+
+	return time.Unix(int64(epoch), 0), nil
+}
+
+// toArray converts an extracted value to the array of forecast points.
+func toArray(value any) ([]any, error) {
+	points, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("points %v (%T) are not an array", value, value)
+	}
+
+	return points, nil
+}
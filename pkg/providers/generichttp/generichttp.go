@@ -0,0 +1,311 @@
+package generichttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	"github.com/rekuberate-io/carbon/pkg/common"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	zonePlaceholder    = "{zone}"
+	defaultResolution  = time.Hour
+	defaultHorizon     = 24 * time.Hour
+	maxResponsePayload = 10 << 20
+)
+
+type GenericHTTPProvider struct {
+	currentUrl       string
+	value            expression
+	timestamp        expression
+	forecastUrl      string
+	forecastPoints   expression
+	forecastValue    expression
+	forecastTime     expression
+	forecastHorizon  time.Duration
+	headers          http.Header
+	params           url.Values
+	conversionFactor float64
+	emissionsType    common.EmissionsType
+	resolution       time.Duration
+	zones            []string
+	client           *http.Client
+}
+
+// NewProvider returns a provider for the GenericHTTP object, authenticated
+// with the values of the given Secret, which is nil if the object does not
+// configure any authentication.
+func NewProvider(o carbonv1alpha1.GenericHTTP, secret *corev1.Secret) (*GenericHTTPProvider, error) {
+	spec := o.Spec
+	p := &GenericHTTPProvider{
+		currentUrl:       spec.Current.URL,
+		headers:          http.Header{},
+		params:           url.Values{},
+		conversionFactor: 1,
+		emissionsType:    common.Average,
+		resolution:       defaultResolution,
+		zones:            spec.Zones,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+
+	var err error
+	if p.value, err = compileExpression(spec.Current.Value); err != nil {
+		return nil, fmt.Errorf("current value: %w", err)
+	}
+
+	if spec.Current.Timestamp != nil {
+		if p.timestamp, err = compileExpression(*spec.Current.Timestamp); err != nil {
+			return nil, fmt.Errorf("current timestamp: %w", err)
+		}
+	}
+
+	if forecast := spec.Forecast; forecast != nil {
+		p.forecastUrl = forecast.URL
+		if p.forecastPoints, err = compileExpression(forecast.Points); err != nil {
+			return nil, fmt.Errorf("forecast points: %w", err)
+		}
+
+		if p.forecastValue, err = compileExpression(forecast.Value); err != nil {
+			return nil, fmt.Errorf("forecast value: %w", err)
+		}
+
+		if p.forecastTime, err = compileExpression(forecast.Timestamp); err != nil {
+			return nil, fmt.Errorf("forecast timestamp: %w", err)
+		}
+
+		p.forecastHorizon = defaultHorizon
+		if forecast.Horizon != nil && forecast.Horizon.Duration > 0 {
+			p.forecastHorizon = forecast.Horizon.Duration
+		}
+	}
+
+	if spec.ConversionFactor != "" {
+		if p.conversionFactor, err = strconv.ParseFloat(spec.ConversionFactor, 64); err != nil {
+			return nil, fmt.Errorf("conversion factor: %w", err)
+		}
+	}
+
+	if spec.EmissionsType != "" {
+		p.emissionsType = common.EmissionsType(spec.EmissionsType)
+	}
+
+	if spec.Resolution != nil && spec.Resolution.Duration > 0 {
+		p.resolution = spec.Resolution.Duration
+	}
+
+	if auth := spec.Auth; auth != nil {
+		if secret == nil {
+			return nil, errors.New("secret of the authentication is missing")
+		}
+
+		for header, key := range auth.Headers {
+			value, ok := secret.Data[key]
+			if !ok {
+				return nil, fmt.Errorf("key '%s' of header '%s' is missing in secret '%s'", key, header, secret.Name)
+			}
+
+			p.headers.Set(header, string(value))
+		}
+
+		for param, key := range auth.QueryParameters {
+			value, ok := secret.Data[key]
+			if !ok {
+				return nil, fmt.Errorf("key '%s' of query parameter '%s' is missing in secret '%s'", key, param, secret.Name)
+			}
+
+			p.params.Set(param, string(value))
+		}
+	}
+
+	return p, nil
+}
+
+// GetCapabilities returns the capabilities of the service, as they are
+// declared by the GenericHTTP object.
+func (p *GenericHTTPProvider) GetCapabilities(ctx context.Context) (*common.Capabilities, error) {
+	return &common.Capabilities{
+		Forecast:        p.forecastUrl != "",
+		ForecastHorizon: p.forecastHorizon,
+		Resolution:      p.resolution,
+		EmissionsTypes:  []common.EmissionsType{p.emissionsType},
+		ZoneNaming:      common.AnyZone,
+	}, nil
+}
+
+// GetZones returns the zones declared by the GenericHTTP object.
+func (p *GenericHTTPProvider) GetZones(ctx context.Context) ([]common.Zone, error) {
+	zones := make([]common.Zone, 0, len(p.zones))
+	for _, zone := range p.zones {
+		zones = append(zones, common.Zone{Name: zone})
+	}
+
+	return zones, nil
+}
+
+// IsValidZone reports whether the zone is declared by the GenericHTTP object;
+// any zone is valid if it declares none.
+func (p *GenericHTTPProvider) IsValidZone(ctx context.Context, zone string) (bool, error) {
+	return len(p.zones) == 0 || slices.Contains(p.zones, zone), nil
+}
+
+func (p *GenericHTTPProvider) GetCurrent(ctx context.Context, zone string) (*common.Reading, error) {
+	var document any
+	if err := p.get(ctx, p.currentUrl, zone, &document); err != nil {
+		return nil, err
+	}
+
+	value, err := p.value.evaluate(document)
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract current value: %w", err)
+	}
+
+	carbonIntensity, err := toFloat(value)
+	if err != nil {
+		return nil, err
+	}
+
+	pointTime := time.Now()
+	if p.timestamp != nil {
+		timestamp, err := p.timestamp.evaluate(document)
+		if err != nil {
+			return nil, fmt.Errorf("unable to extract current timestamp: %w", err)
+		}
+
+		if pointTime, err = toTime(timestamp); err != nil {
+			return nil, err
+		}
+	}
+
+	reading := &common.Reading{
+		Zone:          zone,
+		Value:         carbonIntensity * p.conversionFactor,
+		Unit:          common.GramsPerKilowattHour,
+		SignalType:    p.signalType(),
+		EmissionsType: p.emissionsType,
+		PointTime:     pointTime,
+		Frequency:     p.resolution,
+	}
+
+	return reading, nil
+}
+
+func (p *GenericHTTPProvider) GetForecast(ctx context.Context, zone string) (*common.Forecast, error) {
+	if p.forecastUrl == "" {
+		return nil, errors.New("no forecast configured")
+	}
+
+	var document any
+	if err := p.get(ctx, p.forecastUrl, zone, &document); err != nil {
+		return nil, err
+	}
+
+	results, err := p.forecastPoints.evaluateArray(document)
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract forecast points: %w", err)
+	}
+
+	points := make([]common.ForecastPoint, 0, len(results))
+	for i, result := range results {
+		value, err := p.forecastValue.evaluate(result)
+		if err != nil {
+			return nil, fmt.Errorf("unable to extract value of forecast point %d: %w", i, err)
+		}
+
+		carbonIntensity, err := toFloat(value)
+		if err != nil {
+			return nil, fmt.Errorf("forecast point %d: %w", i, err)
+		}
+
+		timestamp, err := p.forecastTime.evaluate(result)
+		if err != nil {
+			return nil, fmt.Errorf("unable to extract timestamp of forecast point %d: %w", i, err)
+		}
+
+		pointTime, err := toTime(timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("forecast point %d: %w", i, err)
+		}
+
+		points = append(points, common.ForecastPoint{PointTime: pointTime, Value: carbonIntensity * p.conversionFactor})
+	}
+
+	forecast := common.NewForecast(
+		zone,
+		time.Now(),
+		common.GramsPerKilowattHour,
+		p.signalType(),
+		p.emissionsType,
+		p.resolution,
+		points,
+	)
+
+	return forecast, nil
+}
+
+func (p *GenericHTTPProvider) signalType() common.SignalType {
+	if p.emissionsType == common.Marginal {
+		return common.MarginalOperatingRate
+	}
+
+	return common.CarbonIntensity
+}
+
+// get sends a GET request to the given URL, with the zone filled in and the
+// credentials added, and decodes the JSON response into result.
+func (p *GenericHTTPProvider) get(ctx context.Context, rawUrl string, zone string, result any) error {
+	requestUrl, err := url.Parse(strings.ReplaceAll(rawUrl, zonePlaceholder, url.PathEscape(zone)))
+	if err != nil {
+		return err
+	}
+
+	if len(p.params) > 0 {
+		query := requestUrl.Query()
+		for param, values := range p.params {
+			query[param] = values
+		}
+		requestUrl.RawQuery = query.Encode()
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestUrl.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	for header, values := range p.headers {
+		request.Header[header] = values
+	}
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("%w: %s", common.ErrInvalidCredentials, response.Status)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return errors.New(response.Status)
+	}
+
+	bytes, err := io.ReadAll(io.LimitReader(response.Body, maxResponsePayload))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(bytes, result)
+}
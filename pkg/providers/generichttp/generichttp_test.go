@@ -0,0 +1,166 @@
+package generichttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/zones/plant-a/current", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" || r.URL.Query().Get("tenant") != "carbon" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"data":{"intensity":"400","observedAt":"2023-06-01T12:00:00Z"}}`))
+	})
+	mux.HandleFunc("/zones/plant-a/forecast", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"forecast":[
+			{"from":1685624400,"intensity":300},
+			{"from":1685620800,"intensity":200},
+			{"from":1685628000,"intensity":null}
+		]}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestGetCurrentAndForecast(t *testing.T) {
+	server := newTestServer(t)
+
+	o := carbonv1alpha1.GenericHTTP{
+		Spec: carbonv1alpha1.GenericHTTPSpec{
+			Current: carbonv1alpha1.GenericHTTPCurrent{
+				URL:       server.URL + "/zones/{zone}/current",
+				Value:     carbonv1alpha1.GenericHTTPExpression{JSONPath: ".data.intensity"},
+				Timestamp: &carbonv1alpha1.GenericHTTPExpression{JSONPath: "{.data.observedAt}"},
+			},
+			Forecast: &carbonv1alpha1.GenericHTTPForecast{
+				URL:       server.URL + "/zones/{zone}/forecast",
+				Points:    carbonv1alpha1.GenericHTTPExpression{CEL: "self.forecast.filter(p, p.intensity != null)"},
+				Value:     carbonv1alpha1.GenericHTTPExpression{CEL: "self.intensity"},
+				Timestamp: carbonv1alpha1.GenericHTTPExpression{JSONPath: "{.from}"},
+			},
+			Auth: &carbonv1alpha1.GenericHTTPAuth{
+				SecretRef:       &corev1.SecretReference{Name: "credentials"},
+				Headers:         map[string]string{"Authorization": "header"},
+				QueryParameters: map[string]string{"tenant": "tenant"},
+			},
+			ConversionFactor: "0.5",
+		},
+	}
+	secret := &corev1.Secret{Data: map[string][]byte{"header": []byte("Bearer secret"), "tenant": []byte("carbon")}}
+
+	provider, err := NewProvider(o, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reading, err := provider.GetCurrent(context.Background(), "plant-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reading.Value != 200 {
+		t.Errorf("value = %v, want 200", reading.Value)
+	}
+	if want := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC); !reading.PointTime.Equal(want) {
+		t.Errorf("point time = %s, want %s", reading.PointTime, want)
+	}
+
+	forecast, err := provider.GetForecast(context.Background(), "plant-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(forecast.Points) != 2 {
+		t.Fatalf("got %d forecast points, want 2", len(forecast.Points))
+	}
+	if forecast.Points[0].Value != 100 || forecast.Points[1].Value != 150 {
+		t.Errorf("forecast values = %v, %v, want 100, 150", forecast.Points[0].Value, forecast.Points[1].Value)
+	}
+	if forecast.Resolution != time.Hour {
+		t.Errorf("resolution = %s, want 1h", forecast.Resolution)
+	}
+}
+
+func TestCompileExpression(t *testing.T) {
+	tests := []struct {
+		expression carbonv1alpha1.GenericHTTPExpression
+		wantErr    bool
+	}{
+		{expression: carbonv1alpha1.GenericHTTPExpression{JSONPath: "{.data[0].value}"}},
+		{expression: carbonv1alpha1.GenericHTTPExpression{CEL: "double(self.value) * 2.0"}},
+		{expression: carbonv1alpha1.GenericHTTPExpression{JSONPath: "{.data[0"}, wantErr: true},
+		{expression: carbonv1alpha1.GenericHTTPExpression{CEL: "self.value +"}, wantErr: true},
+		{expression: carbonv1alpha1.GenericHTTPExpression{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		if err := CompileExpression(tt.expression); (err != nil) != tt.wantErr {
+			t.Errorf("CompileExpression(%+v) error = %v, wantErr %v", tt.expression, err, tt.wantErr)
+		}
+	}
+}
+
+func TestForecastPointCounts(t *testing.T) {
+	forecasts := map[string]string{
+		"none": `{"forecast":[]}`,
+		"one":  `{"forecast":[{"from":1685620800,"intensity":200}]}`,
+		"two":  `{"forecast":[{"from":1685620800,"intensity":200},{"from":1685624400,"intensity":300}]}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(forecasts[r.URL.Query().Get("zone")]))
+	}))
+	t.Cleanup(server.Close)
+
+	expressions := []carbonv1alpha1.GenericHTTPExpression{
+		{JSONPath: "{.forecast}"},
+		{JSONPath: "{.forecast[*]}"},
+		{CEL: "self.forecast"},
+	}
+
+	for _, points := range expressions {
+		o := carbonv1alpha1.GenericHTTP{
+			Spec: carbonv1alpha1.GenericHTTPSpec{
+				Current: carbonv1alpha1.GenericHTTPCurrent{
+					URL:   server.URL + "/current?zone={zone}",
+					Value: carbonv1alpha1.GenericHTTPExpression{JSONPath: "{.intensity}"},
+				},
+				Forecast: &carbonv1alpha1.GenericHTTPForecast{
+					URL:       server.URL + "/forecast?zone={zone}",
+					Points:    points,
+					Value:     carbonv1alpha1.GenericHTTPExpression{JSONPath: "{.intensity}"},
+					Timestamp: carbonv1alpha1.GenericHTTPExpression{JSONPath: "{.from}"},
+				},
+			},
+		}
+
+		provider, err := NewProvider(o, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for zone, want := range map[string]int{"none": 0, "one": 1, "two": 2} {
+			forecast, err := provider.GetForecast(context.Background(), zone)
+			if err != nil {
+				t.Errorf("%+v, %s: %v", points, zone, err)
+				continue
+			}
+
+			if len(forecast.Points) != want {
+				t.Errorf("%+v, %s: got %d forecast points, want %d", points, zone, len(forecast.Points), want)
+			}
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package nationalgrid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	"github.com/rekuberate-io/carbon/pkg/common"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+const (
+	nationalGridBaseUrl string = "https://api.carbonintensity.org.uk/"
+	// NationalZone is the zone of the national carbon intensity of Great Britain
+	NationalZone          = "GB"
+	minRegionID           = 1
+	maxRegionID           = 17
+	windowLength          = 30 * time.Minute
+	forecastHorizon       = 48 * time.Hour
+	windowTimeQueryLayout = "2006-01-02T15:04Z"
+)
+
+var (
+	// outwardCodePattern matches the outward code of a UK postcode, the API
+	// does not accept full postcodes
+	outwardCodePattern = regexp.MustCompile(`^[A-Z]{1,2}[0-9][A-Z0-9]?$`)
+)
+
+type zoneKind int
+
+const (
+	national zoneKind = iota
+	regional
+	postcode
+)
+
+type NationalGridProvider struct {
+	baseUrl *url.URL
+	client  *http.Client
+}
+
+// NewProvider returns a provider for the NationalGrid object. The Carbon
+// Intensity API is public and needs no credentials.
+func NewProvider(o carbonv1alpha1.NationalGrid) (*NationalGridProvider, error) {
+	baseUrl := nationalGridBaseUrl
+	if o.Spec.Endpoint != nil && *o.Spec.Endpoint != "" {
+		baseUrl = *o.Spec.Endpoint
+	}
+
+	u, err := url.Parse(baseUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NationalGridProvider{
+		baseUrl: u,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}, nil
+}
+
+// GetCapabilities returns the capabilities of the Carbon Intensity API; it
+// publishes average carbon intensities and forecasts of 48 hours, in
+// half-hour settlement periods.
+func (p *NationalGridProvider) GetCapabilities(ctx context.Context) (*common.Capabilities, error) {
+	return &common.Capabilities{
+		Forecast:        true,
+		ForecastHorizon: forecastHorizon,
+		Resolution:      windowLength,
+		EmissionsTypes:  []common.EmissionsType{common.Average},
+		ZoneNaming:      common.NationalGridZone,
+	}, nil
+}
+
+// GetCurrent returns the carbon intensity of the current settlement period.
+// The actual intensity is only published nationally, and only once the period
+// is over; until then the forecast of the period is reported as an estimate.
+func (p *NationalGridProvider) GetCurrent(ctx context.Context, zone string) (*common.Reading, error) {
+	kind, err := parseZone(zone)
+	if err != nil {
+		return nil, err
+	}
+
+	var windows []Window
+	switch kind {
+	case national:
+		var result IntensityResult
+		if err := p.get(ctx, "/intensity", &result); err != nil {
+			return nil, err
+		}
+
+		windows = result.Data
+	case regional, postcode:
+		path := "/regional/regionid/" + zone
+		if kind == postcode {
+			path = "/regional/postcode/" + zone
+		}
+
+		var result RegionalResult
+		if err := p.get(ctx, path, &result); err != nil {
+			return nil, err
+		}
+
+		if len(result.Data) > 0 {
+			windows = result.Data[0].Data
+		}
+	}
+
+	if len(windows) == 0 {
+		return nil, fmt.Errorf("no current data for zone '%s'", zone)
+	}
+
+	window := windows[len(windows)-1]
+	reading := &common.Reading{
+		Zone:          zone,
+		Unit:          common.GramsPerKilowattHour,
+		SignalType:    common.CarbonIntensity,
+		EmissionsType: common.Average,
+		PointTime:     window.From.Time,
+		Frequency:     windowLength,
+	}
+
+	switch {
+	case window.Intensity.Actual != nil:
+		reading.Value = float64(*window.Intensity.Actual)
+	case window.Intensity.Forecast != nil:
+		reading.Value = float64(*window.Intensity.Forecast)
+		reading.IsEstimated = true
+		reading.EstimationMethod = "forecast"
+	default:
+		return nil, fmt.Errorf("no carbon intensity for zone '%s' at %s", zone, window.From.Format(time.RFC3339))
+	}
+
+	return reading, nil
+}
+
+// GetForecast returns the forecast of the next 48 hours, starting with the
+// current settlement period.
+func (p *NationalGridProvider) GetForecast(ctx context.Context, zone string) (*common.Forecast, error) {
+	kind, err := parseZone(zone)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	from := now.Truncate(windowLength).Format(windowTimeQueryLayout)
+
+	var windows []Window
+	switch kind {
+	case national:
+		var result IntensityResult
+		if err := p.get(ctx, fmt.Sprintf("/intensity/%s/fw48h", from), &result); err != nil {
+			return nil, err
+		}
+
+		windows = result.Data
+	case regional, postcode:
+		path := fmt.Sprintf("/regional/intensity/%s/fw48h/regionid/%s", from, zone)
+		if kind == postcode {
+			path = fmt.Sprintf("/regional/intensity/%s/fw48h/postcode/%s", from, zone)
+		}
+
+		var result RegionalForecastResult
+		if err := p.get(ctx, path, &result); err != nil {
+			return nil, err
+		}
+
+		windows = result.Data.Data
+	}
+
+	points := make([]common.ForecastPoint, 0, len(windows))
+	for _, window := range windows {
+		if window.Intensity.Forecast == nil {
+			continue
+		}
+
+		points = append(points, common.ForecastPoint{
+			PointTime: window.From.Time,
+			Value:     float64(*window.Intensity.Forecast),
+		})
+	}
+
+	forecast := common.NewForecast(
+		zone,
+		now,
+		common.GramsPerKilowattHour,
+		common.CarbonIntensity,
+		common.Average,
+		windowLength,
+		points,
+	)
+
+	return forecast, nil
+}
+
+// GetZones returns the national zone and the regions of the API; postcodes
+// are valid zones as well, but can not be listed.
+func (p *NationalGridProvider) GetZones(ctx context.Context) ([]common.Zone, error) {
+	var result RegionsResult
+	if err := p.get(ctx, "/regional", &result); err != nil {
+		return nil, err
+	}
+
+	zones := []common.Zone{{Name: NationalZone, DisplayName: "Great Britain"}}
+	if len(result.Data) == 0 {
+		return zones, nil
+	}
+
+	for _, region := range result.Data[0].Regions {
+		if region.RegionID < minRegionID || region.RegionID > maxRegionID {
+			continue
+		}
+
+		zones = append(zones, common.Zone{Name: strconv.Itoa(region.RegionID), DisplayName: region.ShortName})
+	}
+
+	return zones, nil
+}
+
+// IsValidZone reports whether the zone is the national zone, a region ID or
+// the outward code of a postcode.
+func (p *NationalGridProvider) IsValidZone(ctx context.Context, zone string) (bool, error) {
+	_, err := parseZone(zone)
+	return err == nil, nil
+}
+
+// parseZone returns how the zone is queried, national, by region ID or by
+// postcode.
+func parseZone(zone string) (zoneKind, error) {
+	if zone == NationalZone {
+		return national, nil
+	}
+
+	if regionID, err := strconv.Atoi(zone); err == nil {
+		if regionID < minRegionID || regionID > maxRegionID {
+			return 0, fmt.Errorf("region ID %d is not between %d and %d", regionID, minRegionID, maxRegionID)
+		}
+
+		return regional, nil
+	}
+
+	if outwardCodePattern.MatchString(zone) {
+		return postcode, nil
+	}
+
+	return 0, fmt.Errorf("zone '%s' is neither %s, a region ID nor the outward code of a postcode", zone, NationalZone)
+}
+
+// get sends a GET request to the given path of the Carbon Intensity API and
+// decodes the JSON response into result.
+func (p *NationalGridProvider) get(ctx context.Context, path string, result any) error {
+	requestUrl := common.ResolveAbsoluteUriReference(p.baseUrl, &url.URL{Path: path})
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestUrl.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Add("Accept", "application/json")
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return p.responseError(response)
+	}
+
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(bytes, result)
+}
+
+// responseError returns the error of a failed request, including the error
+// payload of the Carbon Intensity API if there is one.
+func (p *NationalGridProvider) responseError(response *http.Response) error {
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return errors.New(response.Status)
+	}
+
+	var errorPayload struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(bytes, &errorPayload); err != nil || errorPayload.Error.Message == "" {
+		return errors.New(response.Status)
+	}
+
+	return fmt.Errorf("%s; %s: %s", response.Status, errorPayload.Error.Code, errorPayload.Error.Message)
+}
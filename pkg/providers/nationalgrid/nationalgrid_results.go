@@ -0,0 +1,86 @@
+package nationalgrid
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// windowTimeLayouts are the layouts of the window boundaries of the API, which
+// are given in minutes, e.g. 2018-01-20T12:00Z
+var windowTimeLayouts = []string{"2006-01-02T15:04Z07:00", time.RFC3339}
+
+type WindowTime struct {
+	time.Time
+}
+
+func (t *WindowTime) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	var err error
+	for _, layout := range windowTimeLayouts {
+		var parsed time.Time
+		parsed, err = time.Parse(layout, s)
+		if err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+
+	return err
+}
+
+type Intensity struct {
+	Forecast *int   `json:"forecast"`
+	Actual   *int   `json:"actual"`
+	Index    string `json:"index"`
+}
+
+type Fuel struct {
+	Fuel       string  `json:"fuel"`
+	Percentage float64 `json:"perc"`
+}
+
+// Window is the carbon intensity of a half-hour settlement period
+type Window struct {
+	From          WindowTime `json:"from"`
+	To            WindowTime `json:"to"`
+	Intensity     Intensity  `json:"intensity"`
+	GenerationMix []Fuel     `json:"generationmix,omitempty"`
+}
+
+type IntensityResult struct {
+	Data []Window `json:"data"`
+}
+
+type Region struct {
+	RegionID  int      `json:"regionid"`
+	DNORegion string   `json:"dnoregion"`
+	ShortName string   `json:"shortname"`
+	Postcode  string   `json:"postcode,omitempty"`
+	Data      []Window `json:"data"`
+}
+
+type RegionalResult struct {
+	Data []Region `json:"data"`
+}
+
+type RegionalForecastResult struct {
+	Data Region `json:"data"`
+}
+
+type RegionsResult struct {
+	Data []struct {
+		From    WindowTime `json:"from"`
+		To      WindowTime `json:"to"`
+		Regions []struct {
+			RegionID      int       `json:"regionid"`
+			DNORegion     string    `json:"dnoregion"`
+			ShortName     string    `json:"shortname"`
+			Intensity     Intensity `json:"intensity"`
+			GenerationMix []Fuel    `json:"generationmix"`
+		} `json:"regions"`
+	} `json:"data"`
+}
@@ -0,0 +1,97 @@
+package nationalgrid_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	"github.com/rekuberate-io/carbon/pkg/providers/nationalgrid"
+	"github.com/rekuberate-io/carbon/pkg/providers/nationalgrid/nationalgridtest"
+)
+
+// newTestProvider returns a provider reading from a stand-in of the Carbon
+// Intensity API, which serves the national actual intensity and the regional
+// forecasts offset by the id of each region.
+func newTestProvider(t *testing.T) *nationalgrid.NationalGridProvider {
+	server := nationalgridtest.NewServer()
+	t.Cleanup(server.Close)
+
+	endpoint := server.URL
+	o := carbonv1alpha1.NationalGrid{Spec: carbonv1alpha1.NationalGridSpec{Endpoint: &endpoint}}
+	provider, err := nationalgrid.NewProvider(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return provider
+}
+
+func TestGetCurrent(t *testing.T) {
+	provider := newTestProvider(t)
+
+	tests := []struct {
+		zone          string
+		want          float64
+		wantEstimated bool
+	}{
+		{zone: nationalgrid.NationalZone, want: nationalgridtest.NationalActual},
+		{zone: "13", want: nationalgridtest.RegionalForecast + 13, wantEstimated: true},
+		{zone: "RG10", want: nationalgridtest.RegionalForecast + nationalgridtest.PostcodeRegionID, wantEstimated: true},
+	}
+
+	for _, tt := range tests {
+		reading, err := provider.GetCurrent(context.Background(), tt.zone)
+		if err != nil {
+			t.Fatalf("zone %s: %v", tt.zone, err)
+		}
+
+		if reading.Value != tt.want || reading.IsEstimated != tt.wantEstimated {
+			t.Errorf("zone %s: value = %v (estimated %v), want %v (estimated %v)",
+				tt.zone, reading.Value, reading.IsEstimated, tt.want, tt.wantEstimated)
+		}
+
+		if reading.PointTime.IsZero() || reading.PointTime.Minute()%30 != 0 {
+			t.Errorf("zone %s: point time %s is not the start of a settlement period", tt.zone, reading.PointTime)
+		}
+	}
+}
+
+func TestGetForecast(t *testing.T) {
+	provider := newTestProvider(t)
+
+	for _, zone := range []string{nationalgrid.NationalZone, "1", "SW1A"} {
+		forecast, err := provider.GetForecast(context.Background(), zone)
+		if err != nil {
+			t.Fatalf("zone %s: %v", zone, err)
+		}
+
+		if forecast.Resolution != 30*time.Minute {
+			t.Errorf("zone %s: resolution = %s, want 30m", zone, forecast.Resolution)
+		}
+
+		if forecast.Horizon < 47*time.Hour {
+			t.Errorf("zone %s: horizon = %s, want 48h", zone, forecast.Horizon)
+		}
+	}
+}
+
+func TestZones(t *testing.T) {
+	provider := newTestProvider(t)
+
+	zones, err := provider.GetZones(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the nation and regions 1-17, without the duplicate GB region
+	if len(zones) != 18 {
+		t.Errorf("got %d zones, want 18", len(zones))
+	}
+
+	for zone, want := range map[string]bool{"GB": true, "17": true, "18": false, "RG10": true, "rg10": false, "DE": false} {
+		if valid, _ := provider.IsValidZone(context.Background(), zone); valid != want {
+			t.Errorf("zone %s: valid = %v, want %v", zone, valid, want)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+// Package nationalgridtest provides a stand-in for the Carbon Intensity API of
+// the National Grid ESO, for tests that must not reach the public API.
+package nationalgridtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// NationalActual is the actual national carbon intensity of the current
+	// settlement period
+	NationalActual = 182
+	// NationalForecast is the forecast national carbon intensity of every
+	// settlement period
+	NationalForecast = 190
+	// RegionalForecast is the forecast carbon intensity of every settlement
+	// period of a region, increased by the region ID
+	RegionalForecast = 100
+	// PostcodeRegionID is the region every postcode lies in
+	PostcodeRegionID = 12
+
+	windowLength    = 30 * time.Minute
+	forecastWindows = 96
+	timeLayout      = "2006-01-02T15:04Z"
+)
+
+var regionNames = []string{
+	"North Scotland", "South Scotland", "North West England", "North East England", "Yorkshire",
+	"North Wales & Merseyside", "South Wales", "West Midlands", "East Midlands", "East England",
+	"South West England", "South England", "London", "South East England", "England", "Scotland", "Wales",
+}
+
+// NewServer starts a stand-in of the Carbon Intensity API, serving the current
+// intensity and 48 hour forecasts of the nation, regions and postcodes, and
+// the list of regions. The caller must close the server.
+func NewServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/intensity", func(w http.ResponseWriter, r *http.Request) {
+		write(w, map[string]any{"data": []any{window(currentWindow(), NationalForecast, NationalActual)}})
+	})
+	mux.HandleFunc("/intensity/", func(w http.ResponseWriter, r *http.Request) {
+		// /intensity/{from}/fw48h
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/intensity/"), "/")
+		from, err := time.Parse(timeLayout, parts[0])
+		if len(parts) != 2 || parts[1] != "fw48h" || err != nil {
+			writeError(w, http.StatusBadRequest, "invalid forecast request")
+			return
+		}
+
+		write(w, map[string]any{"data": forecast(from, NationalForecast)})
+	})
+	mux.HandleFunc("/regional", func(w http.ResponseWriter, r *http.Request) {
+		regions := make([]any, 0, len(regionNames)+1)
+		for i := range regionNames {
+			regions = append(regions, region(i+1, nil))
+		}
+		regions = append(regions, map[string]any{"regionid": 18, "dnoregion": "GB", "shortname": "GB"})
+
+		start := currentWindow()
+		write(w, map[string]any{"data": []any{map[string]any{
+			"from":    start.Format(timeLayout),
+			"to":      start.Add(windowLength).Format(timeLayout),
+			"regions": regions,
+		}}})
+	})
+	mux.HandleFunc("/regional/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/regional/"), "/")
+		switch {
+		case len(parts) == 2 && parts[0] == "regionid":
+			// /regional/regionid/{regionid}
+			regionID, ok := parseRegionID(parts[1])
+			if !ok {
+				writeError(w, http.StatusBadRequest, "invalid region id")
+				return
+			}
+
+			window := window(currentWindow(), RegionalForecast+regionID, 0)
+			write(w, map[string]any{"data": []any{region(regionID, []any{window})}})
+		case len(parts) == 2 && parts[0] == "postcode":
+			// /regional/postcode/{postcode}
+			window := window(currentWindow(), RegionalForecast+PostcodeRegionID, 0)
+			postcode := region(PostcodeRegionID, []any{window})
+			postcode["postcode"] = parts[1]
+			write(w, map[string]any{"data": []any{postcode}})
+		case len(parts) == 5 && parts[0] == "intensity" && parts[2] == "fw48h":
+			// /regional/intensity/{from}/fw48h/{regionid|postcode}/{id}
+			from, err := time.Parse(timeLayout, parts[1])
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "invalid forecast request")
+				return
+			}
+
+			regionID := PostcodeRegionID
+			if parts[3] == "regionid" {
+				var ok bool
+				if regionID, ok = parseRegionID(parts[4]); !ok {
+					writeError(w, http.StatusBadRequest, "invalid region id")
+					return
+				}
+			}
+
+			data := region(regionID, forecast(from, RegionalForecast+regionID))
+			if parts[3] == "postcode" {
+				data["postcode"] = parts[4]
+			}
+			write(w, map[string]any{"data": data})
+		default:
+			writeError(w, http.StatusNotFound, "not found")
+		}
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func currentWindow() time.Time {
+	return time.Now().UTC().Truncate(windowLength)
+}
+
+func window(from time.Time, forecast int, actual int) map[string]any {
+	intensity := map[string]any{"forecast": forecast, "actual": nil, "index": "moderate"}
+	if actual > 0 {
+		intensity["actual"] = actual
+	}
+
+	return map[string]any{
+		"from":      from.Format(timeLayout),
+		"to":        from.Add(windowLength).Format(timeLayout),
+		"intensity": intensity,
+	}
+}
+
+func forecast(from time.Time, value int) []any {
+	windows := make([]any, 0, forecastWindows)
+	for i := 0; i < forecastWindows; i++ {
+		windows = append(windows, window(from.Add(time.Duration(i)*windowLength), value, 0))
+	}
+
+	return windows
+}
+
+func region(regionID int, data []any) map[string]any {
+	r := map[string]any{
+		"regionid":  regionID,
+		"dnoregion": regionNames[regionID-1],
+		"shortname": regionNames[regionID-1],
+	}
+	if data != nil {
+		r["data"] = data
+	}
+
+	return r
+}
+
+func parseRegionID(s string) (int, bool) {
+	regionID, err := strconv.Atoi(s)
+	if err != nil || regionID < 1 || regionID > len(regionNames) {
+		return 0, false
+	}
+
+	return regionID, true
+}
+
+func write(w http.ResponseWriter, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(payload)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{
+		"error": map[string]string{"code": fmt.Sprintf("%d", status), "message": message},
+	})
+}
@@ -0,0 +1,96 @@
+package providers
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// poolKey identifies the state of a provider object a provider was built
+// from; a change of the spec or of the Secret or ConfigMap the provider reads
+// invalidates the provider.
+type poolKey struct {
+	uid                   types.UID
+	generation            int64
+	sourceResourceVersion string
+}
+
+// objectName identifies a provider object by its lower case kind, namespace and
+// name, which unlike its UID are still known once the object is deleted.
+type objectName struct {
+	kind string
+	types.NamespacedName
+}
+
+type pooledProvider struct {
+	key      poolKey
+	provider Provider
+}
+
+// pool shares providers, and with them their HTTP clients and logins, across
+// all issuers that reference the same provider object.
+type pool struct {
+	mu      sync.Mutex
+	entries map[types.UID]pooledProvider
+	uids    map[objectName]types.UID
+}
+
+var providerPool = newPool()
+
+func newPool() *pool {
+	return &pool{entries: map[types.UID]pooledProvider{}, uids: map[objectName]types.UID{}}
+}
+
+// nameOf returns the name of a provider object, taking its kind from its type
+// as typed objects read through the client lack their TypeMeta.
+func nameOf(o client.Object) objectName {
+	kind := reflect.Indirect(reflect.ValueOf(o)).Type().Name()
+	return objectName{
+		kind:           strings.ToLower(kind),
+		NamespacedName: types.NamespacedName{Namespace: o.GetNamespace(), Name: o.GetName()},
+	}
+}
+
+func (p *pool) get(key poolKey) (Provider, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry, ok := p.entries[key.uid]
+	if !ok || entry.key != key {
+		return nil, false
+	}
+
+	return entry.provider, true
+}
+
+func (p *pool) set(name objectName, key poolKey, provider Provider) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// a provider object recreated under the same name gets a new UID
+	if uid, ok := p.uids[name]; ok && uid != key.uid {
+		delete(p.entries, uid)
+	}
+
+	p.uids[name] = key.uid
+	p.entries[key.uid] = pooledProvider{key: key, provider: provider}
+}
+
+func (p *pool) evict(name objectName) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if uid, ok := p.uids[name]; ok {
+		delete(p.entries, uid)
+		delete(p.uids, name)
+	}
+}
+
+// EvictProvider drops the pooled provider of the provider object of the given
+// kind and name, e.g. after the object was deleted.
+func EvictProvider(kind string, name types.NamespacedName) {
+	providerPool.evict(objectName{kind: strings.ToLower(kind), NamespacedName: name})
+}
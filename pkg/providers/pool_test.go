@@ -0,0 +1,61 @@
+package providers
+
+import (
+	"testing"
+
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestEvictProvider(t *testing.T) {
+	o := &carbonv1alpha1.Simulator{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "simulator", UID: "5f0c1e2a", Generation: 1}}
+
+	created := 0
+	create := func() (Provider, error) {
+		created++
+		return &stubProvider{}, nil
+	}
+
+	first, _ := getOrCreatePooledProvider(o, "", create)
+	if p, _ := getOrCreatePooledProvider(o, "", create); p != first || created != 1 {
+		t.Fatalf("created %d providers, want the pooled one to be reused", created)
+	}
+
+	// the controller only knows the name of a deleted object
+	EvictProvider("Simulator", types.NamespacedName{Namespace: "default", Name: "simulator"})
+
+	if p, _ := getOrCreatePooledProvider(o, "", create); p == first || created != 2 {
+		t.Errorf("created %d providers, want the evicted one to be rebuilt", created)
+	}
+}
+
+func TestPoolReplacesRecreatedObject(t *testing.T) {
+	p := newPool()
+	o := &carbonv1alpha1.Simulator{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "simulator", UID: "5f0c1e2a"}}
+	recreated := o.DeepCopy()
+	recreated.UID = "9d3b7a64"
+
+	p.set(nameOf(o), poolKey{uid: o.UID}, &stubProvider{})
+	p.set(nameOf(recreated), poolKey{uid: recreated.UID}, &stubProvider{})
+
+	if _, ok := p.get(poolKey{uid: o.UID}); ok || len(p.entries) != 1 {
+		t.Errorf("pool holds %d providers, want only the one of the recreated object", len(p.entries))
+	}
+}
+
+func TestNilProviderIsNotPooled(t *testing.T) {
+	o := &carbonv1alpha1.Simulator{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unsupported", UID: "0b8e4f17"}}
+
+	p, err := getOrCreatePooledProvider(o, "", func() (Provider, error) {
+		var provider *stubProvider
+		return provider, nil
+	})
+	if p != nil || err == nil {
+		t.Errorf("provider = %#v, %v, want an untyped nil and an error", p, err)
+	}
+
+	if _, ok := providerPool.get(poolKey{uid: o.UID}); ok {
+		t.Error("nil provider was pooled")
+	}
+}
@@ -0,0 +1,360 @@
+package prometheusquery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	"github.com/rekuberate-io/carbon/pkg/common"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	zonePlaceholder    = "{zone}"
+	defaultTimeLabel   = "point_time"
+	defaultResolution  = 5 * time.Minute
+	defaultHorizon     = 24 * time.Hour
+	maxResponsePayload = 10 << 20
+
+	AuthBearer = "bearer"
+	AuthBasic  = "basic"
+
+	secretKeyToken    = "token"
+	secretKeyUsername = "username"
+	secretKeyPassword = "password"
+)
+
+// zoneEscaper escapes a zone for a double quoted PromQL string, so that a zone
+// cannot alter the query it is filled into
+var zoneEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+type PrometheusQueryProvider struct {
+	queryUrl        string
+	query           string
+	forecastQuery   string
+	timeLabel       string
+	forecastHorizon time.Duration
+	emissionsType   common.EmissionsType
+	resolution      time.Duration
+	zones           []string
+	authorize       func(request *http.Request)
+	client          *http.Client
+}
+
+// NewProvider returns a provider for the PrometheusQuery object, authenticated
+// with the values of the given Secret, which is nil if the object does not
+// configure any authentication.
+func NewProvider(o carbonv1alpha1.PrometheusQuery, secret *corev1.Secret) (*PrometheusQueryProvider, error) {
+	spec := o.Spec
+	address, err := url.Parse(spec.Address)
+	if err != nil {
+		return nil, fmt.Errorf("address: %w", err)
+	}
+
+	p := &PrometheusQueryProvider{
+		queryUrl:      address.JoinPath("api", "v1", "query").String(),
+		query:         spec.Query,
+		emissionsType: common.Average,
+		resolution:    defaultResolution,
+		zones:         spec.Zones,
+		authorize:     func(request *http.Request) {},
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+
+	if forecast := spec.Forecast; forecast != nil {
+		p.forecastQuery = forecast.Query
+
+		p.timeLabel = defaultTimeLabel
+		if forecast.TimeLabel != "" {
+			p.timeLabel = forecast.TimeLabel
+		}
+
+		p.forecastHorizon = defaultHorizon
+		if forecast.Horizon != nil && forecast.Horizon.Duration > 0 {
+			p.forecastHorizon = forecast.Horizon.Duration
+		}
+	}
+
+	if spec.EmissionsType != "" {
+		p.emissionsType = common.EmissionsType(spec.EmissionsType)
+	}
+
+	if spec.Resolution != nil && spec.Resolution.Duration > 0 {
+		p.resolution = spec.Resolution.Duration
+	}
+
+	if auth := spec.Auth; auth != nil {
+		if secret == nil {
+			return nil, errors.New("secret of the authentication is missing")
+		}
+
+		if p.authorize, err = newAuthorizer(auth.Type, secret); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// newAuthorizer returns a function adding the credentials of the Secret to a
+// request, as bearer token or basic auth.
+func newAuthorizer(authType string, secret *corev1.Secret) (func(request *http.Request), error) {
+	value := func(key string) (string, error) {
+		v, ok := secret.Data[key]
+		if !ok {
+			return "", fmt.Errorf("key '%s' is missing in secret '%s'", key, secret.Name)
+		}
+
+		return string(v), nil
+	}
+
+	switch authType {
+	case AuthBearer, "":
+		token, err := value(secretKeyToken)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(request *http.Request) {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}, nil
+	case AuthBasic:
+		username, err := value(secretKeyUsername)
+		if err != nil {
+			return nil, err
+		}
+
+		password, err := value(secretKeyPassword)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(request *http.Request) {
+			request.SetBasicAuth(username, password)
+		}, nil
+	default:
+		return nil, fmt.Errorf("not supported authentication type '%s'", authType)
+	}
+}
+
+// GetCapabilities returns the capabilities of the queries, as they are
+// declared by the PrometheusQuery object.
+func (p *PrometheusQueryProvider) GetCapabilities(ctx context.Context) (*common.Capabilities, error) {
+	return &common.Capabilities{
+		Forecast:        p.forecastQuery != "",
+		ForecastHorizon: p.forecastHorizon,
+		Resolution:      p.resolution,
+		EmissionsTypes:  []common.EmissionsType{p.emissionsType},
+		ZoneNaming:      common.AnyZone,
+	}, nil
+}
+
+// GetZones returns the zones declared by the PrometheusQuery object.
+func (p *PrometheusQueryProvider) GetZones(ctx context.Context) ([]common.Zone, error) {
+	zones := make([]common.Zone, 0, len(p.zones))
+	for _, zone := range p.zones {
+		zones = append(zones, common.Zone{Name: zone})
+	}
+
+	return zones, nil
+}
+
+// IsValidZone reports whether the zone is declared by the PrometheusQuery
+// object; any zone is valid if it declares none.
+func (p *PrometheusQueryProvider) IsValidZone(ctx context.Context, zone string) (bool, error) {
+	return len(p.zones) == 0 || slices.Contains(p.zones, zone), nil
+}
+
+// CheckCredentials verifies that the endpoint is reachable and accepts the
+// credentials by evaluating a constant query.
+func (p *PrometheusQueryProvider) CheckCredentials(ctx context.Context) error {
+	_, err := p.queryInstant(ctx, "1", time.Now())
+	return err
+}
+
+func (p *PrometheusQueryProvider) GetCurrent(ctx context.Context, zone string) (*common.Reading, error) {
+	data, err := p.queryInstant(ctx, fillZone(p.query, zone), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var sample Sample
+	switch data.ResultType {
+	case resultTypeScalar:
+		if err := json.Unmarshal(data.Result, &sample); err != nil {
+			return nil, err
+		}
+	case resultTypeVector:
+		var vector []VectorSample
+		if err := json.Unmarshal(data.Result, &vector); err != nil {
+			return nil, err
+		}
+
+		if len(vector) != 1 {
+			return nil, fmt.Errorf("query returned %d series instead of exactly one", len(vector))
+		}
+
+		sample = vector[0].Value
+	default:
+		return nil, fmt.Errorf("query returned a %s instead of a scalar or vector", data.ResultType)
+	}
+
+	if math.IsNaN(sample.Value) || math.IsInf(sample.Value, 0) {
+		return nil, fmt.Errorf("query returned %v instead of a carbon intensity", sample.Value)
+	}
+
+	reading := &common.Reading{
+		Zone:          zone,
+		Value:         sample.Value,
+		Unit:          common.GramsPerKilowattHour,
+		SignalType:    p.signalType(),
+		EmissionsType: p.emissionsType,
+		PointTime:     sample.Time,
+		Frequency:     p.resolution,
+	}
+
+	return reading, nil
+}
+
+// GetForecast evaluates the forecast query and returns a point for every
+// series, at the time given by its time label.
+func (p *PrometheusQueryProvider) GetForecast(ctx context.Context, zone string) (*common.Forecast, error) {
+	if p.forecastQuery == "" {
+		return nil, errors.New("no forecast configured")
+	}
+
+	now := time.Now()
+	data, err := p.queryInstant(ctx, fillZone(p.forecastQuery, zone), now)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.ResultType != resultTypeVector {
+		return nil, fmt.Errorf("forecast query returned a %s instead of a vector", data.ResultType)
+	}
+
+	var vector []VectorSample
+	if err := json.Unmarshal(data.Result, &vector); err != nil {
+		return nil, err
+	}
+
+	points := make([]common.ForecastPoint, 0, len(vector))
+	for _, series := range vector {
+		label, ok := series.Metric[p.timeLabel]
+		if !ok {
+			return nil, fmt.Errorf("forecast series %v has no label '%s'", series.Metric, p.timeLabel)
+		}
+
+		pointTime, err := parseTime(label)
+		if err != nil {
+			return nil, fmt.Errorf("forecast series %v: %w", series.Metric, err)
+		}
+
+		if math.IsNaN(series.Value.Value) || math.IsInf(series.Value.Value, 0) {
+			continue
+		}
+
+		points = append(points, common.ForecastPoint{PointTime: pointTime, Value: series.Value.Value})
+	}
+
+	forecast := common.NewForecast(
+		zone,
+		now,
+		common.GramsPerKilowattHour,
+		p.signalType(),
+		p.emissionsType,
+		p.resolution,
+		points,
+	)
+
+	return forecast, nil
+}
+
+func (p *PrometheusQueryProvider) signalType() common.SignalType {
+	if p.emissionsType == common.Marginal {
+		return common.MarginalOperatingRate
+	}
+
+	return common.CarbonIntensity
+}
+
+// queryInstant evaluates the query at the given time through the instant
+// query API. The query is sent as form, as it may exceed the length of a URL.
+func (p *PrometheusQueryProvider) queryInstant(ctx context.Context, query string, at time.Time) (*QueryData, error) {
+	form := url.Values{}
+	form.Set("query", query)
+	form.Set("time", strconv.FormatFloat(float64(at.UnixMilli())/1000, 'f', 3, 64))
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, p.queryUrl, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	p.authorize(request)
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("%w: %s", common.ErrInvalidCredentials, response.Status)
+	}
+
+	bytes, err := io.ReadAll(io.LimitReader(response.Body, maxResponsePayload))
+	if err != nil {
+		return nil, err
+	}
+
+	// errors of the query API come with a JSON body of their own, e.g. for bad
+	// queries or timeouts
+	var result QueryResponse
+	if err := json.Unmarshal(bytes, &result); err != nil {
+		if response.StatusCode != http.StatusOK {
+			return nil, errors.New(response.Status)
+		}
+
+		return nil, err
+	}
+
+	if result.Status != "success" {
+		return nil, fmt.Errorf("query failed with %s: %s", result.ErrorType, result.Error)
+	}
+
+	return &result.Data, nil
+}
+
+// fillZone replaces the zone placeholder of the query with the zone, escaped
+// for a double quoted PromQL string, e.g. intensity{zone="{zone}"}.
+func fillZone(query string, zone string) string {
+	return strings.ReplaceAll(query, zonePlaceholder, zoneEscaper.Replace(zone))
+}
+
+// parseTime parses the time label of a forecast series, either RFC 3339 or
+// seconds since the epoch.
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	epoch, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("time '%s' is neither RFC 3339 nor seconds since the epoch", value)
+	}
+
+	return time.UnixMilli(int64(epoch * 1000)), nil
+}
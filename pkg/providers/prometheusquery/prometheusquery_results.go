@@ -0,0 +1,70 @@
+package prometheusquery
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	resultTypeVector = "vector"
+	resultTypeScalar = "scalar"
+)
+
+// QueryResponse is the envelope of every response of the query API.
+type QueryResponse struct {
+	Status    string    `json:"status"`
+	Data      QueryData `json:"data"`
+	ErrorType string    `json:"errorType,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Warnings  []string  `json:"warnings,omitempty"`
+}
+
+type QueryData struct {
+	ResultType string          `json:"resultType"`
+	Result     json.RawMessage `json:"result"`
+}
+
+// VectorSample is a series of an instant vector with its single sample.
+type VectorSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  Sample            `json:"value"`
+}
+
+// Sample is a sample of the query API, encoded as [<unix time>, "<value>"].
+type Sample struct {
+	Time  time.Time
+	Value float64
+}
+
+func (s *Sample) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	if len(raw) != 2 {
+		return fmt.Errorf("sample %s is not a pair of time and value", string(b))
+	}
+
+	var timestamp float64
+	if err := json.Unmarshal(raw[0], &timestamp); err != nil {
+		return fmt.Errorf("time of sample %s: %w", string(b), err)
+	}
+
+	var value string
+	if err := json.Unmarshal(raw[1], &value); err != nil {
+		return fmt.Errorf("value of sample %s: %w", string(b), err)
+	}
+
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("value of sample %s: %w", string(b), err)
+	}
+
+	s.Time = time.UnixMilli(int64(timestamp * 1000))
+	s.Value = v
+
+	return nil
+}
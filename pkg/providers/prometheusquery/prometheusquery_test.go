@@ -0,0 +1,149 @@
+package prometheusquery_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	"github.com/rekuberate-io/carbon/pkg/common"
+	"github.com/rekuberate-io/carbon/pkg/providers/prometheusquery"
+	"github.com/rekuberate-io/carbon/pkg/providers/prometheusquery/prometheusquerytest"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	currentQuery  = `grid_carbon_intensity{zone="{zone}"}`
+	forecastQuery = `grid_carbon_intensity_forecast{zone="{zone}"}`
+)
+
+// newTestServer starts a stand-in answering the queries of site-a with a
+// current carbon intensity and a forecast of 4 hours, whose point_time labels
+// alternate between epoch seconds and RFC 3339, and the current query of
+// site-b with no series at all.
+func newTestServer(t *testing.T) *prometheusquerytest.Server {
+	now := time.Now().Truncate(time.Hour)
+	forecast := make([]prometheusquerytest.Series, 0)
+	for i := 3; i >= 0; i-- {
+		pointTime := now.Add(time.Duration(i) * time.Hour)
+		label := strconv.FormatInt(pointTime.Unix(), 10)
+		if i%2 == 0 {
+			label = pointTime.Format(time.RFC3339)
+		}
+
+		forecast = append(forecast, prometheusquerytest.Series{
+			Labels: map[string]string{"zone": "site-a", "point_time": label},
+			Value:  float64(200 + i*10),
+		})
+	}
+
+	server := prometheusquerytest.NewServer(map[string][]prometheusquerytest.Series{
+		`grid_carbon_intensity{zone="site-a"}`:          {{Labels: map[string]string{"zone": "site-a"}, Value: 231.5}},
+		`grid_carbon_intensity{zone="site-b"}`:          {},
+		`grid_carbon_intensity_forecast{zone="site-a"}`: forecast,
+	})
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newTestProvider(t *testing.T, address string, auth *carbonv1alpha1.PrometheusQueryAuth, secret *corev1.Secret) *prometheusquery.PrometheusQueryProvider {
+	o := carbonv1alpha1.PrometheusQuery{Spec: carbonv1alpha1.PrometheusQuerySpec{
+		Address:  address,
+		Query:    currentQuery,
+		Forecast: &carbonv1alpha1.PrometheusQueryForecast{Query: forecastQuery},
+		Auth:     auth,
+	}}
+
+	provider, err := prometheusquery.NewProvider(o, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return provider
+}
+
+func TestGetCurrent(t *testing.T) {
+	server := newTestServer(t)
+	provider := newTestProvider(t, server.URL, nil, nil)
+
+	reading, err := provider.GetCurrent(context.Background(), "site-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reading.Value != 231.5 || reading.Unit != common.GramsPerKilowattHour {
+		t.Errorf("reading = %v %s, want 231.5 %s", reading.Value, reading.Unit, common.GramsPerKilowattHour)
+	}
+
+	if _, err := provider.GetCurrent(context.Background(), "site-b"); err == nil {
+		t.Error("expected an error for a query without series")
+	}
+
+	if _, err := provider.GetCurrent(context.Background(), "site-c"); err == nil {
+		t.Error("expected an error for a failing query")
+	}
+}
+
+func TestGetForecast(t *testing.T) {
+	server := newTestServer(t)
+	provider := newTestProvider(t, server.URL, nil, nil)
+
+	forecast, err := provider.GetForecast(context.Background(), "site-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(forecast.Points) != 4 {
+		t.Fatalf("forecast has %d points, want 4", len(forecast.Points))
+	}
+
+	for i, point := range forecast.Points {
+		if want := float64(200 + i*10); point.Value != want {
+			t.Errorf("point %d = %v, want %v", i, point.Value, want)
+		}
+	}
+
+	if forecast.Resolution != time.Hour {
+		t.Errorf("resolution = %s, want 1h", forecast.Resolution)
+	}
+}
+
+func TestZoneIsEscaped(t *testing.T) {
+	server := newTestServer(t)
+	provider := newTestProvider(t, server.URL, nil, nil)
+
+	provider.GetCurrent(context.Background(), `x"} or vector(1) or {a="`)
+
+	want := `grid_carbon_intensity{zone="x\"} or vector(1) or {a=\""}`
+	if got := server.Queries[len(server.Queries)-1]; got != want {
+		t.Errorf("query = %s, want %s", got, want)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	server := newTestServer(t)
+	server.BearerToken = "secret-token"
+
+	secret := &corev1.Secret{Data: map[string][]byte{"token": []byte("secret-token")}}
+	auth := &carbonv1alpha1.PrometheusQueryAuth{Type: prometheusquery.AuthBearer, SecretRef: &corev1.SecretReference{Name: "prometheus"}}
+	if err := newTestProvider(t, server.URL, auth, secret).CheckCredentials(context.Background()); err != nil {
+		t.Errorf("bearer token rejected: %v", err)
+	}
+
+	secret = &corev1.Secret{Data: map[string][]byte{"token": []byte("wrong")}}
+	err := newTestProvider(t, server.URL, auth, secret).CheckCredentials(context.Background())
+	if !errors.Is(err, common.ErrInvalidCredentials) {
+		t.Errorf("err = %v, want %v", err, common.ErrInvalidCredentials)
+	}
+
+	server.BearerToken = ""
+	server.Username, server.Password = "carbon", "password"
+	secret = &corev1.Secret{Data: map[string][]byte{"username": []byte("carbon"), "password": []byte("password")}}
+	auth = &carbonv1alpha1.PrometheusQueryAuth{Type: prometheusquery.AuthBasic, SecretRef: &corev1.SecretReference{Name: "prometheus"}}
+	if _, err := newTestProvider(t, server.URL, auth, secret).GetCurrent(context.Background(), "site-a"); err != nil {
+		t.Errorf("basic auth rejected: %v", err)
+	}
+}
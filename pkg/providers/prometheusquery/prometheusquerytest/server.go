@@ -0,0 +1,131 @@
+// Package prometheusquerytest provides a stand-in for the query API of
+// Prometheus, for tests that must not depend on a running Prometheus.
+package prometheusquerytest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+)
+
+// Series is a series of an instant vector, with its labels and value.
+type Series struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// Server is a stand-in of the instant query API. It answers the queries of
+// Results, numeric literals as scalars, and any other query with bad_data.
+type Server struct {
+	*httptest.Server
+
+	// Results maps a query to the series it returns
+	Results map[string][]Series
+	// BearerToken is required on every request if it is set
+	BearerToken string
+	// Username and Password are required on every request if they are set
+	Username string
+	Password string
+	// Queries records the queries the server received
+	Queries []string
+}
+
+// NewServer starts a stand-in of the query API answering the given queries.
+// The caller must close the server.
+func NewServer(results map[string][]Series) *Server {
+	s := &Server{Results: results}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/query", s.query)
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+func (s *Server) query(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_data", err.Error())
+		return
+	}
+
+	query := r.Form.Get("query")
+	s.Queries = append(s.Queries, query)
+
+	at := time.Now()
+	if t := r.Form.Get("time"); t != "" {
+		seconds, err := strconv.ParseFloat(t, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "bad_data", "invalid time")
+			return
+		}
+		at = time.UnixMilli(int64(seconds * 1000))
+	}
+	timestamp := float64(at.UnixMilli()) / 1000
+
+	if value, err := strconv.ParseFloat(query, 64); err == nil {
+		write(w, "scalar", []any{timestamp, format(value)})
+		return
+	}
+
+	series, ok := s.Results[query]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "bad_data", "unknown query: "+query)
+		return
+	}
+
+	vector := make([]any, 0, len(series))
+	for _, v := range series {
+		labels := v.Labels
+		if labels == nil {
+			labels = map[string]string{}
+		}
+
+		vector = append(vector, map[string]any{
+			"metric": labels,
+			"value":  []any{timestamp, format(v.Value)},
+		})
+	}
+
+	write(w, "vector", vector)
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if s.BearerToken != "" {
+		return r.Header.Get("Authorization") == "Bearer "+s.BearerToken
+	}
+
+	if s.Username != "" || s.Password != "" {
+		username, password, ok := r.BasicAuth()
+		return ok && username == s.Username && password == s.Password
+	}
+
+	return true
+}
+
+func format(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func write(w http.ResponseWriter, resultType string, result any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"status": "success",
+		"data":   map[string]any{"resultType": resultType, "result": result},
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, errorType string, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{
+		"status":    "error",
+		"errorType": errorType,
+		"error":     message,
+	})
+}
@@ -4,10 +4,19 @@ import (
 	"context"
 	"fmt"
 	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	"github.com/rekuberate-io/carbon/pkg/common"
+	"github.com/rekuberate-io/carbon/pkg/emissionfactors"
+	"github.com/rekuberate-io/carbon/pkg/providers/eia"
 	"github.com/rekuberate-io/carbon/pkg/providers/electricitymaps"
+	"github.com/rekuberate-io/carbon/pkg/providers/entsoe"
+	"github.com/rekuberate-io/carbon/pkg/providers/generichttp"
+	"github.com/rekuberate-io/carbon/pkg/providers/nationalgrid"
+	"github.com/rekuberate-io/carbon/pkg/providers/prometheusquery"
 	"github.com/rekuberate-io/carbon/pkg/providers/simulator"
+	"github.com/rekuberate-io/carbon/pkg/providers/statictable"
 	"github.com/rekuberate-io/carbon/pkg/providers/watttime"
 	v1 "k8s.io/api/core/v1"
+	"reflect"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"slices"
@@ -21,29 +30,50 @@ const (
 	WattTime        ProviderType = "watttime"
 	ElectricityMaps ProviderType = "electricitymaps"
 	Simulator       ProviderType = "simulator"
+	NationalGrid    ProviderType = "nationalgrid"
+	GenericHTTP     ProviderType = "generichttp"
+	StaticTable     ProviderType = "statictable"
+	PrometheusQuery ProviderType = "prometheusquery"
+	Composite       ProviderType = "compositeprovider"
+	ENTSOE          ProviderType = "entsoe"
+	EIA             ProviderType = "eia"
 )
 
 var (
-	supportedProviders     = []ProviderType{WattTime, ElectricityMaps, Simulator}
+	supportedProviders     = []ProviderType{WattTime, ElectricityMaps, Simulator, NationalGrid, GenericHTTP, StaticTable, PrometheusQuery, Composite, ENTSOE, EIA}
 	supportedEmissionTypes = []EmissionsType{Average, Marginal}
 )
 
 type Provider interface {
-	GetCurrent(ctx context.Context, zone string) (float64, error)
-	GetForecast(ctx context.Context, zone string) (map[time.Time]float64, error)
+	GetCapabilities(ctx context.Context) (*common.Capabilities, error)
+	GetZones(ctx context.Context) ([]common.Zone, error)
+	GetCurrent(ctx context.Context, zone string) (*common.Reading, error)
+	GetForecast(ctx context.Context, zone string) (*common.Forecast, error)
 }
 
-//
-//type Forecast struct {
-//	PointTime       time.Time `json:"pointTime"`
-//	CarbonIntensity float64   `json:"carbonIntensity"`
-//}
+// CredentialsChecker is implemented by providers that authenticate against
+// their API. Errors of rejected credentials wrap common.ErrInvalidCredentials.
+type CredentialsChecker interface {
+	CheckCredentials(ctx context.Context) error
+}
 
 func GetProvider(
 	ctx context.Context,
 	req ctrl.Request,
 	kClient client.Client,
 	providerRef *v1.ObjectReference,
+) (Provider, error) {
+	return getProvider(ctx, req, kClient, providerRef, nil)
+}
+
+// getProvider returns the provider of the referenced provider object, with path
+// holding the composite provider objects that reference it.
+func getProvider(
+	ctx context.Context,
+	req ctrl.Request,
+	kClient client.Client,
+	providerRef *v1.ObjectReference,
+	path compositePath,
 ) (Provider, error) {
 	providerRefKind := strings.ToLower(providerRef.Kind)
 	if providerRefKind == "" {
@@ -70,39 +100,328 @@ func GetProvider(
 			return nil, err
 		}
 
-		p, err := simulator.NewProvider(*po)
-		if err != nil {
-			return nil, err
-		}
-
-		return Provider(p), nil
+		return getOrCreateProvider(po, nil, func() (Provider, error) {
+			return simulator.NewProvider(*po)
+		})
 	case string(WattTime):
 		po := &carbonv1alpha1.WattTime{}
 		if err := kClient.Get(ctx, objectKey, po); err != nil {
 			return nil, err
 		}
 
-		p, err := watttime.NewProvider(ctx, kClient, *po)
+		secret, err := getSecret(ctx, kClient, po.Spec.Password, po.Namespace)
 		if err != nil {
 			return nil, err
 		}
 
-		return Provider(p), nil
+		return getOrCreateProvider(po, secret, func() (Provider, error) {
+			return watttime.NewProvider(ctx, *po, secret)
+		})
 	case string(ElectricityMaps):
 		po := &carbonv1alpha1.ElectricityMaps{}
 		if err := kClient.Get(ctx, objectKey, po); err != nil {
 			return nil, err
 		}
 
-		p, err := electricitymaps.NewProvider(ctx, kClient, *po)
+		secret, err := getSecret(ctx, kClient, po.Spec.ApiKeyRef, po.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		return getOrCreateProvider(po, secret, func() (Provider, error) {
+			return electricitymaps.NewProvider(*po, secret)
+		})
+	case string(NationalGrid):
+		po := &carbonv1alpha1.NationalGrid{}
+		if err := kClient.Get(ctx, objectKey, po); err != nil {
+			return nil, err
+		}
+
+		return getOrCreateProvider(po, nil, func() (Provider, error) {
+			return nationalgrid.NewProvider(*po)
+		})
+	case string(GenericHTTP):
+		po := &carbonv1alpha1.GenericHTTP{}
+		if err := kClient.Get(ctx, objectKey, po); err != nil {
+			return nil, err
+		}
+
+		// authentication is optional
+		var secret *v1.Secret
+		if po.Spec.Auth != nil {
+			var err error
+			secret, err = getSecret(ctx, kClient, po.Spec.Auth.SecretRef, po.Namespace)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return getOrCreateProvider(po, secret, func() (Provider, error) {
+			return generichttp.NewProvider(*po, secret)
+		})
+	case string(StaticTable):
+		po := &carbonv1alpha1.StaticTable{}
+		if err := kClient.Get(ctx, objectKey, po); err != nil {
+			return nil, err
+		}
+
+		data, resourceVersion, err := getStaticTableData(ctx, kClient, po)
+		if err != nil {
+			return nil, err
+		}
+
+		return getOrCreatePooledProvider(po, resourceVersion, func() (Provider, error) {
+			return statictable.NewProvider(*po, data)
+		})
+	case string(PrometheusQuery):
+		po := &carbonv1alpha1.PrometheusQuery{}
+		if err := kClient.Get(ctx, objectKey, po); err != nil {
+			return nil, err
+		}
+
+		// authentication is optional
+		var secret *v1.Secret
+		if po.Spec.Auth != nil {
+			var err error
+			secret, err = getSecret(ctx, kClient, po.Spec.Auth.SecretRef, po.Namespace)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return getOrCreateProvider(po, secret, func() (Provider, error) {
+			return prometheusquery.NewProvider(*po, secret)
+		})
+	case string(Composite):
+		po := &carbonv1alpha1.CompositeProvider{}
+		if err := kClient.Get(ctx, objectKey, po); err != nil {
+			return nil, err
+		}
+
+		if path.contains(po.Namespace, po.Name) {
+			return nil, fmt.Errorf("%w: %s", ErrProviderCycle, strings.Join(path.with(po.Namespace, po.Name), " > "))
+		}
+
+		// composites are not pooled, their members are and may change on
+		// their own
+		path = path.with(po.Namespace, po.Name)
+		memberReq := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(po)}
+
+		return newCompositeProvider(po, func(m carbonv1alpha1.CompositeProviderMember) (Provider, error) {
+			return getProvider(ctx, memberReq, kClient, m.ProviderRef.DeepCopy(), path)
+		}), nil
+	case string(ENTSOE):
+		po := &carbonv1alpha1.ENTSOE{}
+		if err := kClient.Get(ctx, objectKey, po); err != nil {
+			return nil, err
+		}
+
+		secret, err := getSecret(ctx, kClient, po.Spec.SecurityTokenRef, po.Namespace)
 		if err != nil {
 			return nil, err
 		}
 
-		return Provider(p), nil
+		factors, resourceVersion, err := getEmissionFactorSet(ctx, kClient, po.Spec.EmissionFactorSetRef)
+		if err != nil {
+			return nil, err
+		}
+
+		return getOrCreatePooledProvider(po, secret.ResourceVersion+"/"+resourceVersion, func() (Provider, error) {
+			return entsoe.NewProvider(*po, secret, factors)
+		})
+	case string(EIA):
+		po := &carbonv1alpha1.EIA{}
+		if err := kClient.Get(ctx, objectKey, po); err != nil {
+			return nil, err
+		}
+
+		secret, err := getSecret(ctx, kClient, po.Spec.ApiKeyRef, po.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		factors, resourceVersion, err := getEmissionFactorSet(ctx, kClient, po.Spec.EmissionFactorSetRef)
+		if err != nil {
+			return nil, err
+		}
+
+		return getOrCreatePooledProvider(po, secret.ResourceVersion+"/"+resourceVersion, func() (Provider, error) {
+			return eia.NewProvider(*po, secret, factors)
+		})
+	}
+
+	return nil, fmt.Errorf("not supported carbon intensity provider")
+}
+
+// getOrCreateProvider returns the pooled provider of the provider object, or
+// creates and pools a new one if the object or its Secret changed since.
+func getOrCreateProvider(o client.Object, secret *v1.Secret, create func() (Provider, error)) (Provider, error) {
+	resourceVersion := ""
+	if secret != nil {
+		resourceVersion = secret.ResourceVersion
+	}
+
+	return getOrCreatePooledProvider(o, resourceVersion, create)
+}
+
+// getOrCreatePooledProvider returns the pooled provider of the provider
+// object, or creates and pools a new one if the object or the resource version
+// of the Secrets, ConfigMaps or EmissionFactorSets it reads changed since.
+func getOrCreatePooledProvider(o client.Object, sourceResourceVersion string, create func() (Provider, error)) (Provider, error) {
+	key := poolKey{uid: o.GetUID(), generation: o.GetGeneration(), sourceResourceVersion: sourceResourceVersion}
+
+	if p, ok := providerPool.get(key); ok {
+		return p, nil
+	}
+
+	name := nameOf(o)
+	p, err := create()
+	if err != nil {
+		return nil, err
+	}
+
+	if isNil(p) {
+		return nil, fmt.Errorf("unable to initialize provider %s (%s)", name.NamespacedName, name.kind)
+	}
+
+	providerPool.set(name, key, p)
+	return p, nil
+}
+
+// isNil reports whether the provider is nil, also if it is a nil pointer of a
+// provider type, which the constructors of the providers return with errors.
+func isNil(p Provider) bool {
+	if p == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(p)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+// getSecret returns the referenced Secret, which defaults to the namespace of
+// the provider object.
+func getSecret(ctx context.Context, kClient client.Client, ref *v1.SecretReference, namespace string) (*v1.Secret, error) {
+	if ref == nil {
+		return nil, fmt.Errorf("secret reference is missing")
+	}
+
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+
+	secret := &v1.Secret{}
+	if err := kClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+// getEmissionFactorSet returns the set of the referenced EmissionFactorSet
+// along with its resource version, or the default set if there is no
+// reference.
+func getEmissionFactorSet(ctx context.Context, kClient client.Client, ref *v1.LocalObjectReference) (*emissionfactors.Set, string, error) {
+	if ref == nil {
+		return emissionfactors.Default(), "", nil
+	}
+
+	o := &carbonv1alpha1.EmissionFactorSet{}
+	if err := kClient.Get(ctx, client.ObjectKey{Name: ref.Name}, o); err != nil {
+		return nil, "", err
+	}
+
+	factors, err := emissionfactors.NewSet(o)
+	if err != nil {
+		return nil, "", fmt.Errorf("emission factor set '%s': %w", o.Name, err)
+	}
+
+	return factors, o.ResourceVersion, nil
+}
+
+// getStaticTableData returns the table of a StaticTable that is read from a
+// ConfigMap or Secret, along with the resource version of its source. Tables in
+// files are read by the provider itself.
+func getStaticTableData(ctx context.Context, kClient client.Client, po *carbonv1alpha1.StaticTable) ([]byte, string, error) {
+	source := po.Spec.Source
+	switch {
+	case source.ConfigMapKeyRef != nil:
+		configMap := &v1.ConfigMap{}
+		objectKey := client.ObjectKey{Namespace: po.Namespace, Name: source.ConfigMapKeyRef.Name}
+		if err := kClient.Get(ctx, objectKey, configMap); err != nil {
+			return nil, "", err
+		}
+
+		if data, ok := configMap.Data[source.ConfigMapKeyRef.Key]; ok {
+			return []byte(data), configMap.ResourceVersion, nil
+		}
+
+		if data, ok := configMap.BinaryData[source.ConfigMapKeyRef.Key]; ok {
+			return data, configMap.ResourceVersion, nil
+		}
+
+		return nil, "", fmt.Errorf("key '%s' is missing in configmap '%s'", source.ConfigMapKeyRef.Key, configMap.Name)
+	case source.SecretKeyRef != nil:
+		secret := &v1.Secret{}
+		objectKey := client.ObjectKey{Namespace: po.Namespace, Name: source.SecretKeyRef.Name}
+		if err := kClient.Get(ctx, objectKey, secret); err != nil {
+			return nil, "", err
+		}
+
+		data, ok := secret.Data[source.SecretKeyRef.Key]
+		if !ok {
+			return nil, "", fmt.Errorf("key '%s' is missing in secret '%s'", source.SecretKeyRef.Key, secret.Name)
+		}
+
+		return data, secret.ResourceVersion, nil
+	}
+
+	return nil, "", nil
+}
+
+// GetProviderObject returns the provider object the reference points to, which
+// defaults to the given namespace.
+func GetProviderObject(
+	ctx context.Context,
+	kClient client.Client,
+	providerRef *v1.ObjectReference,
+	namespace string,
+) (client.Object, error) {
+	var po client.Object
+	switch strings.ToLower(providerRef.Kind) {
+	case string(Simulator):
+		po = &carbonv1alpha1.Simulator{}
+	case string(WattTime):
+		po = &carbonv1alpha1.WattTime{}
+	case string(ElectricityMaps):
+		po = &carbonv1alpha1.ElectricityMaps{}
+	case string(NationalGrid):
+		po = &carbonv1alpha1.NationalGrid{}
+	case string(GenericHTTP):
+		po = &carbonv1alpha1.GenericHTTP{}
+	case string(StaticTable):
+		po = &carbonv1alpha1.StaticTable{}
+	case string(PrometheusQuery):
+		po = &carbonv1alpha1.PrometheusQuery{}
+	case string(Composite):
+		po = &carbonv1alpha1.CompositeProvider{}
+	case string(ENTSOE):
+		po = &carbonv1alpha1.ENTSOE{}
+	case string(EIA):
+		po = &carbonv1alpha1.EIA{}
+	default:
+		return nil, fmt.Errorf("not supported carbon intensity provider")
 	}
 
-	return nil, nil
+	if providerRef.Namespace != "" {
+		namespace = providerRef.Namespace
+	}
+
+	if err := kClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: providerRef.Name}, po); err != nil {
+		return nil, err
+	}
+
+	return po, nil
 }
 
 func GetSupportedProviders() []ProviderType {
@@ -116,3 +435,15 @@ func IsSupported(providerType string) bool {
 
 	return true
 }
+
+// HistoryProvider is implemented by providers that serve past carbon
+// intensities of a zone. Readings are returned oldest first.
+type HistoryProvider interface {
+	GetHistory(ctx context.Context, zone string, start time.Time, end time.Time) ([]common.Reading, error)
+}
+
+// PowerBreakdownProvider is implemented by providers that report the origin of
+// the electricity of a zone.
+type PowerBreakdownProvider interface {
+	GetPowerBreakdown(ctx context.Context, zone string) (*common.PowerBreakdown, error)
+}
@@ -0,0 +1,13 @@
+// Package providertest provides helpers shared by the tests of the providers.
+package providertest
+
+import "math"
+
+// Tolerance is the difference up to which carbon intensities count as equal,
+// as the tests state them rounded to two decimals.
+const Tolerance = 0.01
+
+// Near reports whether two carbon intensities are equal within Tolerance.
+func Near(a float64, b float64) bool {
+	return math.Abs(a-b) < Tolerance
+}
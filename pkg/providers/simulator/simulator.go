@@ -16,6 +16,13 @@ var (
 
 	//go:embed forecast.json
 	forecast string
+
+	//go:embed zones.json
+	zones string
+)
+
+const (
+	forecastResolution = time.Hour
 )
 
 type Simulator struct {
@@ -25,7 +32,7 @@ type Simulator struct {
 }
 
 func NewProvider(o carbonv1alpha1.Simulator) (*Simulator, error) {
-	randomize := *o.Spec.Randomize
+	randomize := o.Spec.Randomize != nil && *o.Spec.Randomize
 
 	if randomize {
 		var result ForecastResult
@@ -46,37 +53,103 @@ func NewProvider(o carbonv1alpha1.Simulator) (*Simulator, error) {
 	return &Simulator{randomize: randomize}, nil
 }
 
-func (p *Simulator) GetCurrent(ctx context.Context, zone string) (float64, error) {
+func (p *Simulator) GetCurrent(ctx context.Context, zone string) (*common.Reading, error) {
+	var result LiveResult
+	err := json.Unmarshal([]byte(latest), &result)
+	if err != nil {
+		return nil, err
+	}
+
+	// the embedded reading is shifted, so that it always refers to the
+	// current hour
+	now := time.Now()
+	reading := &common.Reading{
+		Zone:               zone,
+		Value:              float64(result.CarbonIntensity),
+		Unit:               common.GramsPerKilowattHour,
+		SignalType:         common.CarbonIntensity,
+		EmissionsType:      common.Average,
+		PointTime:          now.Truncate(forecastResolution),
+		UpdatedAt:          now,
+		IsEstimated:        result.IsEstimated,
+		EstimationMethod:   result.EstimationMethod,
+		EmissionFactorType: common.EmissionFactorType(result.EmissionFactorType),
+	}
+
 	if p.randomize {
-		return rand.Float64() * (p.max - p.min), nil
+		reading.Value = p.min + rand.Float64()*(p.max-p.min)
 	}
 
-	var result LiveResult
-	err := json.Unmarshal([]byte(latest), &result)
+	return reading, nil
+}
+
+func (p *Simulator) GetForecast(ctx context.Context, zone string) (*common.Forecast, error) {
+	var result ForecastResult
+	err := json.Unmarshal([]byte(forecast), &result)
 	if err != nil {
-		return common.NoValue, err
+		return nil, err
+	}
+
+	// the embedded forecast is shifted, so that it always starts at the next
+	// full hour
+	generatedAt := time.Now()
+	pointTime := generatedAt.Truncate(forecastResolution)
+
+	points := make([]common.ForecastPoint, 0, len(result.Forecast))
+	for _, f := range result.Forecast {
+		pointTime = pointTime.Add(forecastResolution)
+
+		value := float64(f.CarbonIntensity)
+		if p.randomize {
+			value = p.min + rand.Float64()*(p.max-p.min)
+		}
+
+		points = append(points, common.ForecastPoint{PointTime: pointTime, Value: value})
 	}
 
-	carbonIntensity := float64(result.CarbonIntensity)
-	return carbonIntensity, nil
+	return common.NewForecast(
+		zone,
+		generatedAt,
+		common.GramsPerKilowattHour,
+		common.CarbonIntensity,
+		common.Average,
+		forecastResolution,
+		points,
+	), nil
 }
 
-func (p *Simulator) GetForecast(ctx context.Context, zone string) (map[time.Time]float64, error) {
+// GetCapabilities returns the capabilities of the simulator; the embedded data
+// set holds average carbon intensities and is served for every embedded zone.
+func (p *Simulator) GetCapabilities(ctx context.Context) (*common.Capabilities, error) {
 	var result ForecastResult
 	err := json.Unmarshal([]byte(forecast), &result)
 	if err != nil {
 		return nil, err
 	}
 
-	forecasts := make(map[time.Time]float64)
-	pointTime := time.Now()
+	return &common.Capabilities{
+		Forecast:        true,
+		ForecastHorizon: time.Duration(len(result.Forecast)) * forecastResolution,
+		Resolution:      forecastResolution,
+		EmissionsTypes:  []common.EmissionsType{common.Average},
+		ZoneNaming:      common.ElectricityMapsZone,
+	}, nil
+}
+
+// GetZones returns the zones embedded in the simulator.
+func (p *Simulator) GetZones(ctx context.Context) ([]common.Zone, error) {
+	var result ZonesResult
+	err := json.Unmarshal([]byte(zones), &result)
+	if err != nil {
+		return nil, err
+	}
 
-	for range result.Forecast {
-		pointTime = pointTime.Add(1 * time.Hour)
-		forecasts[pointTime] = rand.Float64() * (p.max - p.min)
+	zones := make([]common.Zone, 0, len(result))
+	for _, zone := range result {
+		zones = append(zones, common.Zone{Name: zone.Zone, DisplayName: zone.ZoneName})
 	}
 
-	return forecasts, nil
+	return zones, nil
 }
 
 func getMaxMin(results ForecastResult) (int, int) {
@@ -21,3 +21,8 @@ type ForecastResult struct {
 	} `json:"forecast"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
+
+type ZonesResult []struct {
+	Zone     string `json:"zone"`
+	ZoneName string `json:"zoneName"`
+}
@@ -0,0 +1,237 @@
+package statictable
+
+import (
+	"context"
+	"fmt"
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	"github.com/rekuberate-io/carbon/pkg/common"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+const (
+	defaultResolution      = time.Hour
+	defaultForecastHorizon = 24 * time.Hour
+)
+
+type StaticTableProvider struct {
+	load            func() ([]row, error)
+	repeat          Repeat
+	resolution      time.Duration
+	forecastHorizon time.Duration
+	emissionsType   common.EmissionsType
+	now             func() time.Time
+}
+
+// NewProvider returns a provider for the StaticTable object, serving the
+// given table data from its ConfigMap or Secret. Tables in files are read on
+// every request instead, data is nil then.
+func NewProvider(o carbonv1alpha1.StaticTable, data []byte) (*StaticTableProvider, error) {
+	format := Format(o.Spec.Format)
+	p := &StaticTableProvider{
+		repeat:          None,
+		resolution:      defaultResolution,
+		forecastHorizon: defaultForecastHorizon,
+		emissionsType:   common.Average,
+		now:             time.Now,
+	}
+
+	if o.Spec.Repeat != "" {
+		p.repeat = Repeat(o.Spec.Repeat)
+	}
+
+	if o.Spec.Resolution != nil && o.Spec.Resolution.Duration > 0 {
+		p.resolution = o.Spec.Resolution.Duration
+	}
+
+	if o.Spec.ForecastHorizon != nil && o.Spec.ForecastHorizon.Duration > 0 {
+		p.forecastHorizon = o.Spec.ForecastHorizon.Duration
+	}
+
+	if o.Spec.EmissionsType != "" {
+		p.emissionsType = common.EmissionsType(o.Spec.EmissionsType)
+	}
+
+	if file := o.Spec.Source.File; file != "" {
+		if !carbonv1alpha1.IsStaticTableFile(file) {
+			return nil, fmt.Errorf("file '%s' is not relative to %s", file, carbonv1alpha1.StaticTableDirectory)
+		}
+
+		path := filepath.Join(carbonv1alpha1.StaticTableDirectory, file)
+		p.load = func() ([]row, error) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+
+			return parseTable(data, format)
+		}
+
+		return p, nil
+	}
+
+	rows, err := parseTable(data, format)
+	if err != nil {
+		return nil, err
+	}
+
+	p.load = func() ([]row, error) {
+		return rows, nil
+	}
+
+	return p, nil
+}
+
+// GetCapabilities returns the capabilities of the table as they are declared
+// by the StaticTable object.
+func (p *StaticTableProvider) GetCapabilities(ctx context.Context) (*common.Capabilities, error) {
+	return &common.Capabilities{
+		Forecast:        true,
+		ForecastHorizon: p.forecastHorizon,
+		Resolution:      p.resolution,
+		EmissionsTypes:  []common.EmissionsType{p.emissionsType},
+		ZoneNaming:      common.AnyZone,
+	}, nil
+}
+
+// GetZones returns the zones of the rows of the table.
+func (p *StaticTableProvider) GetZones(ctx context.Context) ([]common.Zone, error) {
+	rows, err := p.load()
+	if err != nil {
+		return nil, err
+	}
+
+	zones := make([]common.Zone, 0)
+	for _, r := range rows {
+		if r.Zone != "" && !slices.ContainsFunc(zones, func(z common.Zone) bool { return z.Name == r.Zone }) {
+			zones = append(zones, common.Zone{Name: r.Zone})
+		}
+	}
+
+	return zones, nil
+}
+
+// IsValidZone reports whether the table has rows of the zone, or rows that
+// apply to every zone.
+func (p *StaticTableProvider) IsValidZone(ctx context.Context, zone string) (bool, error) {
+	rows, err := p.load()
+	if err != nil {
+		return false, err
+	}
+
+	return slices.ContainsFunc(rows, func(r row) bool { return r.Zone == "" || r.Zone == zone }), nil
+}
+
+// GetCurrent returns the row of the zone that is valid now.
+func (p *StaticTableProvider) GetCurrent(ctx context.Context, zone string) (*common.Reading, error) {
+	rows, err := p.load()
+	if err != nil {
+		return nil, err
+	}
+
+	now := p.now()
+	current, ok := p.current(rows, zone, now)
+	if !ok {
+		return nil, fmt.Errorf("table has no carbon intensity of zone '%s' for %s", zone, now.Format(time.RFC3339))
+	}
+
+	reading := &common.Reading{
+		Zone:          zone,
+		Value:         current.CarbonIntensity,
+		Unit:          common.GramsPerKilowattHour,
+		SignalType:    p.signalType(),
+		EmissionsType: p.emissionsType,
+		PointTime:     current.Timestamp,
+		Frequency:     p.resolution,
+	}
+
+	return reading, nil
+}
+
+// GetForecast returns the rows of the zone from the one that is valid now up
+// to the forecast horizon.
+func (p *StaticTableProvider) GetForecast(ctx context.Context, zone string) (*common.Forecast, error) {
+	rows, err := p.load()
+	if err != nil {
+		return nil, err
+	}
+
+	now := p.now()
+	from := now
+	if current, ok := p.current(rows, zone, now); ok {
+		from = current.Timestamp
+	}
+
+	future := series(rows, zone, p.repeat, from, now.Add(p.forecastHorizon))
+	points := make([]common.ForecastPoint, 0, len(future))
+	for _, r := range future {
+		points = append(points, common.ForecastPoint{PointTime: r.Timestamp, Value: r.CarbonIntensity})
+	}
+
+	forecast := common.NewForecast(
+		zone,
+		now,
+		common.GramsPerKilowattHour,
+		p.signalType(),
+		p.emissionsType,
+		p.resolution,
+		points,
+	)
+
+	return forecast, nil
+}
+
+// GetHistory returns the rows of the zone between start and end.
+func (p *StaticTableProvider) GetHistory(ctx context.Context, zone string, start time.Time, end time.Time) ([]common.Reading, error) {
+	rows, err := p.load()
+	if err != nil {
+		return nil, err
+	}
+
+	past := series(rows, zone, p.repeat, start, end)
+	readings := make([]common.Reading, 0, len(past))
+	for _, r := range past {
+		readings = append(readings, common.Reading{
+			Zone:          zone,
+			Value:         r.CarbonIntensity,
+			Unit:          common.GramsPerKilowattHour,
+			SignalType:    p.signalType(),
+			EmissionsType: p.emissionsType,
+			PointTime:     r.Timestamp,
+			Frequency:     p.resolution,
+		})
+	}
+
+	return readings, nil
+}
+
+// current returns the latest row of the zone that started at or before now
+// and is still valid.
+func (p *StaticTableProvider) current(rows []row, zone string, now time.Time) (row, bool) {
+	lookBack := p.resolution
+	if period := p.repeat.period(); period > lookBack {
+		lookBack = period
+	}
+
+	past := series(rows, zone, p.repeat, now.Add(-lookBack), now)
+	if len(past) == 0 {
+		return row{}, false
+	}
+
+	latest := past[len(past)-1]
+	if now.Sub(latest.Timestamp) >= p.resolution {
+		return row{}, false
+	}
+
+	return latest, true
+}
+
+func (p *StaticTableProvider) signalType() common.SignalType {
+	if p.emissionsType == common.Marginal {
+		return common.MarginalOperatingRate
+	}
+
+	return common.CarbonIntensity
+}
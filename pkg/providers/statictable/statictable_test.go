@@ -0,0 +1,83 @@
+package statictable
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const dailyTable = `timestamp,carbonIntensity,zone
+2023-01-02T00:00:00Z,300,site-a
+2023-01-02T12:00:00Z,200,site-a
+2023-01-02T00:00:00Z,100,site-b
+`
+
+func newTestProvider(t *testing.T, spec carbonv1alpha1.StaticTableSpec, data string, now time.Time) *StaticTableProvider {
+	p, err := NewProvider(carbonv1alpha1.StaticTable{Spec: spec}, []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.now = func() time.Time { return now }
+	return p
+}
+
+func TestDailyRepetition(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 13, 30, 0, 0, time.UTC)
+	spec := carbonv1alpha1.StaticTableSpec{
+		Format:     "csv",
+		Repeat:     "daily",
+		Resolution: &metav1.Duration{Duration: 12 * time.Hour},
+	}
+	p := newTestProvider(t, spec, dailyTable, now)
+
+	reading, err := p.GetCurrent(context.Background(), "site-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reading.Value != 200 || !reading.PointTime.Equal(time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("got %v at %s, want 200 at 12:00", reading.Value, reading.PointTime)
+	}
+
+	forecast, err := p.GetForecast(context.Background(), "site-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 12:00 today, 00:00 and 12:00 tomorrow
+	want := []float64{200, 300, 200}
+	if len(forecast.Points) != len(want) {
+		t.Fatalf("got %d forecast points, want %d", len(forecast.Points), len(want))
+	}
+	for i, point := range forecast.Points {
+		if point.Value != want[i] {
+			t.Errorf("forecast point %d = %v, want %v", i, point.Value, want[i])
+		}
+	}
+}
+
+func TestNoValueOutsideTable(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 13, 30, 0, 0, time.UTC)
+	p := newTestProvider(t, carbonv1alpha1.StaticTableSpec{Format: "json"},
+		`[{"timestamp":"2024-03-15T12:00:00Z","carbonIntensity":150}]`, now)
+
+	// the row is only valid for the default resolution of one hour
+	if _, err := p.GetCurrent(context.Background(), "any"); err == nil {
+		t.Error("expected an error for a time the table does not cover")
+	}
+
+	if valid, _ := p.IsValidZone(context.Background(), "any"); !valid {
+		t.Error("rows without a zone should apply to every zone")
+	}
+}
+
+func TestFileMustStayInTableDirectory(t *testing.T) {
+	spec := carbonv1alpha1.StaticTableSpec{Source: carbonv1alpha1.StaticTableSource{File: "../../etc/passwd"}}
+	if _, err := NewProvider(carbonv1alpha1.StaticTable{Spec: spec}, nil); err == nil {
+		t.Error("expected an error for a file outside of the table directory")
+	}
+}
@@ -0,0 +1,205 @@
+package statictable
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Format string
+
+const (
+	CSV  Format = "csv"
+	JSON Format = "json"
+)
+
+type Repeat string
+
+const (
+	None   Repeat = "none"
+	Daily  Repeat = "daily"
+	Weekly Repeat = "weekly"
+)
+
+const (
+	columnTimestamp       = "timestamp"
+	columnCarbonIntensity = "carbonIntensity"
+	columnZone            = "zone"
+)
+
+var (
+	// repeatAnchor is the start of a week, repeating tables are aligned to it
+	repeatAnchor = time.Date(2023, time.January, 2, 0, 0, 0, 0, time.UTC)
+)
+
+// row is a carbon intensity of the table, valid from its time for the
+// resolution of the table. Rows without a zone apply to every zone.
+type row struct {
+	Timestamp       time.Time `json:"timestamp"`
+	CarbonIntensity float64   `json:"carbonIntensity"`
+	Zone            string    `json:"zone,omitempty"`
+}
+
+// parseTable parses a table in the given format and sorts its rows by time.
+func parseTable(data []byte, format Format) ([]row, error) {
+	var rows []row
+	var err error
+
+	switch format {
+	case CSV, "":
+		rows, err = parseCSV(data)
+	case JSON:
+		err = json.Unmarshal(data, &rows)
+	default:
+		err = fmt.Errorf("not supported table format '%s'", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("table has no rows")
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].Timestamp.Before(rows[j].Timestamp)
+	})
+
+	return rows, nil
+}
+
+func parseCSV(data []byte) ([]row, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+
+	timestampColumn, ok := columns[columnTimestamp]
+	if !ok {
+		return nil, fmt.Errorf("column '%s' is missing", columnTimestamp)
+	}
+
+	carbonIntensityColumn, ok := columns[columnCarbonIntensity]
+	if !ok {
+		return nil, fmt.Errorf("column '%s' is missing", columnCarbonIntensity)
+	}
+
+	zoneColumn, hasZone := columns[columnZone]
+
+	rows := make([]row, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		if timestampColumn >= len(record) || carbonIntensityColumn >= len(record) {
+			return nil, fmt.Errorf("line %d: too few columns", line)
+		}
+
+		timestamp, err := time.Parse(time.RFC3339, strings.TrimSpace(record[timestampColumn]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: timestamp is not RFC 3339", line)
+		}
+
+		carbonIntensity, err := strconv.ParseFloat(strings.TrimSpace(record[carbonIntensityColumn]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: carbon intensity is not a number", line)
+		}
+
+		r := row{Timestamp: timestamp, CarbonIntensity: carbonIntensity}
+		if hasZone && zoneColumn < len(record) {
+			r.Zone = strings.TrimSpace(record[zoneColumn])
+		}
+
+		rows = append(rows, r)
+	}
+
+	return rows, nil
+}
+
+// period returns the period the table repeats in, 0 if it does not repeat.
+func (r Repeat) period() time.Duration {
+	switch r {
+	case Daily:
+		return 24 * time.Hour
+	case Weekly:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// series returns the sorted rows of the zone between from and to, with the
+// rows of a repeating table placed in every period of that span.
+func series(rows []row, zone string, repeat Repeat, from time.Time, to time.Time) []row {
+	zoneRows := make([]row, 0, len(rows))
+	for _, r := range rows {
+		if r.Zone == "" || r.Zone == zone {
+			zoneRows = append(zoneRows, r)
+		}
+	}
+
+	period := repeat.period()
+	if period == 0 {
+		result := make([]row, 0, len(zoneRows))
+		for _, r := range zoneRows {
+			if !r.Timestamp.Before(from) && !r.Timestamp.After(to) {
+				result = append(result, r)
+			}
+		}
+
+		return result
+	}
+
+	result := make([]row, 0)
+	start := repeatAnchor.Add(floorDiv(from.Sub(repeatAnchor), period) * period)
+	for base := start; !base.After(to); base = base.Add(period) {
+		for _, r := range zoneRows {
+			phase := r.Timestamp.Sub(repeatAnchor) - floorDiv(r.Timestamp.Sub(repeatAnchor), period)*period
+			timestamp := base.Add(phase)
+			if timestamp.Before(from) || timestamp.After(to) {
+				continue
+			}
+
+			result = append(result, row{Timestamp: timestamp, CarbonIntensity: r.CarbonIntensity, Zone: r.Zone})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Timestamp.Before(result[j].Timestamp)
+	})
+
+	return result
+}
+
+// floorDiv divides d by period, rounding towards negative infinity.
+func floorDiv(d time.Duration, period time.Duration) time.Duration {
+	q := d / period
+	if d%period < 0 {
+		q--
+	}
+
+	return q
+}
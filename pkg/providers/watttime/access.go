@@ -0,0 +1,87 @@
+package watttime
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/rekuberate-io/carbon/pkg/common"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// accesses caches the access of the WattTime objects of the cluster, so that
+// providers built for the same object discover it once per
+// accessRefreshInterval.
+var accesses = &accessCache{entries: map[types.UID]cachedAccess{}}
+
+// access is what the account of a WattTime object may query, as discovered
+// from the API; WattTime plans differ in the regions and endpoints they grant
+// access to.
+type access struct {
+	zones      []common.Zone
+	forecast   bool
+	resolution time.Duration
+	fetchedAt  time.Time
+}
+
+type cachedAccess struct {
+	access      access
+	credentials [sha256.Size]byte
+}
+
+type accessCache struct {
+	mu      sync.Mutex
+	entries map[types.UID]cachedAccess
+}
+
+// get returns the access of a WattTime object as long as it was discovered
+// for the same credentials within accessRefreshInterval.
+func (c *accessCache) get(uid types.UID, credentials [sha256.Size]byte) (*access, bool) {
+	if uid == "" {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[uid]
+	if !ok || entry.credentials != credentials || time.Since(entry.access.fetchedAt) >= accessRefreshInterval {
+		return nil, false
+	}
+
+	access := entry.access
+	return &access, true
+}
+
+func (c *accessCache) set(uid types.UID, credentials [sha256.Size]byte, access access) {
+	if uid == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[uid] = cachedAccess{access: access, credentials: credentials}
+}
+
+// forbidForecast records that the plan of a WattTime object turned out not to
+// include forecasts, until the access is discovered again.
+func (c *accessCache) forbidForecast(uid types.UID, credentials [sha256.Size]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[uid]
+	if !ok || entry.credentials != credentials {
+		return
+	}
+
+	entry.access.forecast = false
+	c.entries[uid] = entry
+}
+
+func (c *accessCache) invalidate(uid types.UID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, uid)
+}
@@ -0,0 +1,101 @@
+package watttime
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// defaultTokenLifetime is the lifetime WattTime documents for its tokens,
+	// used when the token does not carry an expiry itself
+	defaultTokenLifetime = 30 * time.Minute
+	tokenExpiryMargin    = time.Minute
+)
+
+// tokens caches the bearer tokens of the WattTime objects of the cluster, so
+// that providers built for the same object share a login.
+var tokens = &tokenCache{entries: map[types.UID]cachedToken{}}
+
+type cachedToken struct {
+	token       string
+	credentials [sha256.Size]byte
+	expiresAt   time.Time
+}
+
+type tokenCache struct {
+	mu      sync.Mutex
+	entries map[types.UID]cachedToken
+}
+
+// get returns the token of a WattTime object as long as it was issued for the
+// same credentials and has not expired.
+func (c *tokenCache) get(uid types.UID, credentials [sha256.Size]byte) (string, bool) {
+	if uid == "" {
+		return "", false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[uid]
+	if !ok || entry.credentials != credentials || time.Now().Add(tokenExpiryMargin).After(entry.expiresAt) {
+		return "", false
+	}
+
+	return entry.token, true
+}
+
+func (c *tokenCache) set(uid types.UID, credentials [sha256.Size]byte, token string) {
+	if uid == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[uid] = cachedToken{token: token, credentials: credentials, expiresAt: tokenExpiry(token)}
+}
+
+func (c *tokenCache) invalidate(uid types.UID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, uid)
+}
+
+// credentialsFingerprint identifies the API and credentials a token was issued
+// for, without keeping the password around.
+func credentialsFingerprint(baseUrl string, username string, password string) [sha256.Size]byte {
+	return sha256.Sum256([]byte(baseUrl + "|" + username + ":" + password))
+}
+
+// tokenExpiry returns the expiry of a JWT token, or the default lifetime of a
+// token if it is not a JWT or carries no expiry.
+func tokenExpiry(token string) time.Time {
+	fallback := time.Now().Add(defaultTokenLifetime)
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return fallback
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return fallback
+	}
+
+	var claims struct {
+		Expiry int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Expiry == 0 {
+		return fallback
+	}
+
+	return time.Unix(claims.Expiry, 0)
+}
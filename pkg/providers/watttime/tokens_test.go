@@ -0,0 +1,109 @@
+package watttime
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestGetLogsInAgainOnUnauthorized(t *testing.T) {
+	var logins atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/login", func(w http.ResponseWriter, r *http.Request) {
+		logins.Add(1)
+		json.NewEncoder(w).Encode(map[string]string{"token": "fresh"})
+	})
+	mux.HandleFunc("/v2/index", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": "unauthorized", "message": "token expired"})
+			return
+		}
+		w.Write([]byte(`{"ba":"CAISO_NORTH","freq":"300","percent":"50","moer":"1000","point_time":"2023-06-01T12:00:00Z"}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	baseUrl, _ := url.Parse(server.URL)
+	uid := types.UID("watttime-sample")
+	credentials := credentialsFingerprint(server.URL, "user", "secret")
+	tokens.set(uid, credentials, "expired")
+	defer tokens.invalidate(uid)
+
+	token, _ := tokens.get(uid, credentials)
+	provider := &WattTimeProvider{
+		apiVersion:  V2,
+		baseUrl:     baseUrl,
+		uid:         uid,
+		username:    "user",
+		password:    "secret",
+		credentials: credentials,
+		token:       token,
+		client:      server.Client(),
+	}
+
+	reading, err := provider.GetCurrent(context.Background(), "CAISO_NORTH")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reading.Zone != "CAISO_NORTH" {
+		t.Errorf("zone = %s, want CAISO_NORTH", reading.Zone)
+	}
+	if logins.Load() != 1 {
+		t.Errorf("logins = %d, want 1", logins.Load())
+	}
+	if token, ok := tokens.get(uid, credentials); !ok || token != "fresh" {
+		t.Errorf("cached token = %q, want fresh", token)
+	}
+	if _, ok := tokens.get(uid, credentialsFingerprint(server.URL, "user", "rotated")); ok {
+		t.Error("token reused for other credentials")
+	}
+}
+
+func TestCheckCredentialsReusesToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  string
+		logins int32
+	}{
+		{name: "valid token", token: "fresh", logins: 0},
+		{name: "expired token", token: "expired", logins: 1},
+		{name: "no token", token: "", logins: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logins atomic.Int32
+			mux := http.NewServeMux()
+			mux.HandleFunc("/v2/login", func(w http.ResponseWriter, r *http.Request) {
+				logins.Add(1)
+				json.NewEncoder(w).Encode(map[string]string{"token": "fresh"})
+			})
+			mux.HandleFunc("/v2/ba-access", func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer fresh" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.Write([]byte(`[{"ba":"CAISO_NORTH","name":"California ISO Northern"}]`))
+			})
+			provider := newTestProvider(t, V2, mux)
+			provider.token = tt.token
+
+			if err := provider.CheckCredentials(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			if logins.Load() != tt.logins {
+				t.Errorf("logins = %d, want %d", logins.Load(), tt.logins)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package watttime
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,54 +10,89 @@ import (
 	"github.com/rekuberate-io/carbon/pkg/common"
 	"io"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"net/http"
 	"net/url"
-	"sigs.k8s.io/controller-runtime/pkg/client"
+	"slices"
 	"strconv"
+	"sync"
 	"time"
 )
 
+type APIVersion string
+
 const (
-	wattTimeBaseUrl           string  = "https://api2.watttime.org/"
-	wattTimeApiVersionUrlPath string  = "/v2"
-	lbsTogramms               float64 = 453.59237
+	V2 APIVersion = "v2"
+	V3 APIVersion = "v3"
+)
+
+const (
+	lbsTogramms           float64 = 453.59237
+	forecastResolution            = 5 * time.Minute
+	forecastHorizon               = 24 * time.Hour
+	rateLimitRequests             = 3000
+	rateLimitPeriod               = 5 * time.Minute
+	accessRefreshInterval         = time.Hour
+)
+
+var (
+	// baseUrls are the hosts of the versions of the WattTime API
+	baseUrls = map[APIVersion]string{
+		V2: "https://api2.watttime.org/",
+		V3: "https://api.watttime.org/",
+	}
+
+	errUnauthorized = errors.New("unauthorized")
+	errForbidden    = errors.New("forbidden")
 )
 
 type WattTimeProvider struct {
-	baseUrl  *url.URL
-	username string
-	password string
-	token    string
-	client   *http.Client
+	apiVersion  APIVersion
+	baseUrl     *url.URL
+	uid         types.UID
+	username    string
+	password    string
+	credentials [sha256.Size]byte
+	client      *http.Client
+
+	// token is shared by all issuers of the WattTime object
+	mu    sync.RWMutex
+	token string
 }
 
-func NewProvider(ctx context.Context, k client.Client, o carbonv1alpha1.WattTime) (*WattTimeProvider, error) {
+// NewProvider returns a provider for the WattTime object, authenticated with
+// the password in the given Secret.
+func NewProvider(ctx context.Context, o carbonv1alpha1.WattTime, secret *corev1.Secret) (*WattTimeProvider, error) {
 	watttime := &WattTimeProvider{client: &http.Client{
 		Timeout: 10 * time.Second,
 	}}
 
-	baseUrl, err := url.Parse(wattTimeBaseUrl)
-	if err != nil {
-		return nil, err
+	watttime.apiVersion = APIVersion(o.Spec.APIVersion)
+	if watttime.apiVersion == "" {
+		watttime.apiVersion = V2
 	}
 
-	passwordRef := o.Spec.Password
-	if passwordRef.Namespace == "" {
-		passwordRef.Namespace = o.Namespace
+	baseUrl, ok := baseUrls[watttime.apiVersion]
+	if !ok {
+		return nil, fmt.Errorf("not supported WattTime API version '%s'", o.Spec.APIVersion)
 	}
 
-	objectKey := client.ObjectKey{
-		Namespace: passwordRef.Namespace,
-		Name:      passwordRef.Name,
-	}
-	secret := &corev1.Secret{}
-	if err := k.Get(ctx, objectKey, secret); err != nil {
+	var err error
+	watttime.baseUrl, err = url.Parse(baseUrl)
+	if err != nil {
 		return nil, err
 	}
 
-	watttime.baseUrl = baseUrl
+	watttime.uid = o.UID
 	watttime.username = o.Spec.Username
 	watttime.password = string(secret.Data["password"])
+	watttime.credentials = credentialsFingerprint(baseUrl, watttime.username, watttime.password)
+
+	// reuse the token of the WattTime object as long as it is valid
+	if token, ok := tokens.get(watttime.uid, watttime.credentials); ok {
+		watttime.token = token
+		return watttime, nil
+	}
 
 	err = watttime.login(ctx)
 	if err != nil {
@@ -66,8 +102,102 @@ func NewProvider(ctx context.Context, k client.Client, o carbonv1alpha1.WattTime
 	return watttime, nil
 }
 
+// GetCapabilities returns the capabilities of WattTime for the account of the
+// object; the MOER signal is always marginal and zones are balancing
+// authorities, while forecasts depend on the plan of the account.
+func (p *WattTimeProvider) GetCapabilities(ctx context.Context) (*common.Capabilities, error) {
+	access, err := p.getAccess(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities := &common.Capabilities{
+		Forecast:       access.forecast,
+		Resolution:     access.resolution,
+		EmissionsTypes: []common.EmissionsType{common.Marginal},
+		ZoneNaming:     common.BalancingAuthority,
+		RateLimit:      &common.RateLimit{Requests: rateLimitRequests, Period: rateLimitPeriod},
+	}
+
+	if access.forecast {
+		capabilities.ForecastHorizon = forecastHorizon
+	}
+
+	return capabilities, nil
+}
+
+// getAccess returns the access of the account, discovering it again once
+// accessRefreshInterval elapsed.
+func (p *WattTimeProvider) getAccess(ctx context.Context) (*access, error) {
+	if access, ok := accesses.get(p.uid, p.credentials); ok {
+		return access, nil
+	}
+
+	var access *access
+	var err error
+	if p.apiVersion == V3 {
+		access, err = p.getAccessV3(ctx)
+	} else {
+		access, err = p.getAccessV2(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	access.fetchedAt = time.Now()
+	accesses.set(p.uid, p.credentials, *access)
+
+	return access, nil
+}
+
+// getAccessV2 returns the balancing authorities the account has access to.
+// The v2 API does not tell whether the plan includes forecasts, so they are
+// assumed until a forecast request of the object is forbidden.
+func (p *WattTimeProvider) getAccessV2(ctx context.Context) (*access, error) {
+	params := url.Values{}
+	params.Add("all", "false")
+
+	var result RegionsResult
+	err := p.get(ctx, "/ba-access", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	access := &access{zones: make([]common.Zone, 0, len(result)), forecast: true, resolution: forecastResolution}
+	for _, region := range result {
+		access.zones = append(access.zones, common.Zone{Name: region.BalancingAuthority, DisplayName: region.Name})
+	}
+
+	return access, nil
+}
+
+// CheckCredentials verifies the username and password with a cheap request
+// of the account, reusing the token of the object; the provider logs in again
+// only if there is no token yet or the token is rejected.
+func (p *WattTimeProvider) CheckCredentials(ctx context.Context) error {
+	p.mu.RLock()
+	token := p.token
+	p.mu.RUnlock()
+
+	if token == "" {
+		return p.login(ctx)
+	}
+
+	if p.apiVersion == V3 {
+		var result V3AccessResult
+		return p.get(ctx, "/my-access", url.Values{}, &result)
+	}
+
+	var result RegionsResult
+	return p.get(ctx, "/ba-access", url.Values{}, &result)
+}
+
 func (p *WattTimeProvider) login(ctx context.Context) error {
-	loginUrl := common.ResolveAbsoluteUriReference(p.baseUrl, &url.URL{Path: wattTimeApiVersionUrlPath}, &url.URL{Path: "/login"})
+	// the login of v3 is not versioned
+	loginUrl := common.ResolveAbsoluteUriReference(p.baseUrl, &url.URL{Path: "/login"})
+	if p.apiVersion == V2 {
+		loginUrl = common.ResolveAbsoluteUriReference(p.baseUrl, &url.URL{Path: "/v2"}, &url.URL{Path: "/login"})
+	}
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, loginUrl.String(), nil)
 	if err != nil {
 		return err
@@ -80,13 +210,14 @@ func (p *WattTimeProvider) login(ctx context.Context) error {
 	}
 	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusOK {
-		apierr, msg, err := p.unwrapHttpResponseErrorPayload(response)
-		if err != nil {
-			return errors.New(response.Status)
-		}
+	if response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden {
+		tokens.invalidate(p.uid)
+		return fmt.Errorf("%w: %s", common.ErrInvalidCredentials, p.responseError(response))
+	}
 
-		return errors.New(fmt.Sprintf("%s; %s: %s", response.Status, apierr, msg))
+	if response.StatusCode != http.StatusOK {
+		tokens.invalidate(p.uid)
+		return p.responseError(response)
 	}
 
 	bytes, err := io.ReadAll(response.Body)
@@ -100,106 +231,232 @@ func (p *WattTimeProvider) login(ctx context.Context) error {
 		return err
 	}
 
+	p.mu.Lock()
 	p.token = tokenAsJson["token"]
+	p.mu.Unlock()
+	tokens.set(p.uid, p.credentials, tokenAsJson["token"])
 
 	return nil
 }
 
-func (p *WattTimeProvider) GetCurrent(ctx context.Context, zone string) (float64, error) {
-	requestUrl := common.ResolveAbsoluteUriReference(p.baseUrl, &url.URL{Path: wattTimeApiVersionUrlPath}, &url.URL{Path: "/index"})
+func (p *WattTimeProvider) GetCurrent(ctx context.Context, zone string) (*common.Reading, error) {
+	if p.apiVersion == V3 {
+		return p.getCurrentV3(ctx, zone)
+	}
+
 	params := url.Values{}
 	params.Add("ba", zone)
-	requestUrl.RawQuery = params.Encode()
 
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestUrl.String(), nil)
+	var result LiveResult
+	err := p.get(ctx, "/index", params, &result)
 	if err != nil {
-		return common.NoValue, err
+		return nil, err
 	}
 
-	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", p.token))
-	response, err := p.client.Do(request)
+	moer, err := strconv.ParseFloat(result.MOER, 64)
 	if err != nil {
-		return common.NoValue, err
+		return nil, err
 	}
-	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusOK {
-		apierr, msg, err := p.unwrapHttpResponseErrorPayload(response)
-		if err != nil {
-			return common.NoValue, errors.New(response.Status)
-		}
+	reading := &common.Reading{
+		Zone:          result.BalancingAuthority,
+		Value:         moer * lbsTogramms / 1000,
+		Unit:          common.GramsPerKilowattHour,
+		SignalType:    common.MarginalOperatingRate,
+		EmissionsType: common.Marginal,
+		PointTime:     result.PointTime,
+	}
 
-		return common.NoValue, errors.New(fmt.Sprintf("%s; %s: %s", response.Status, apierr, msg))
+	if percent, err := strconv.ParseFloat(result.Percent, 64); err == nil {
+		reading.Percent = &percent
 	}
 
-	bytes, err := io.ReadAll(response.Body)
+	if frequency, err := strconv.Atoi(result.Frequency); err == nil {
+		reading.Frequency = time.Duration(frequency) * time.Second
+	}
+
+	return reading, nil
+}
+
+func (p *WattTimeProvider) GetForecast(ctx context.Context, zone string) (*common.Forecast, error) {
+	if p.apiVersion == V3 {
+		return p.getForecastV3(ctx, zone)
+	}
+
+	params := url.Values{}
+	params.Add("ba", zone)
+
+	var result ForecastResult
+	err := p.get(ctx, "/forecast", params, &result)
+	if errors.Is(err, errForbidden) {
+		accesses.forbidForecast(p.uid, p.credentials)
+	}
 	if err != nil {
-		return common.NoValue, err
+		return nil, err
 	}
 
-	var result LiveResult
-	err = json.Unmarshal(bytes, &result)
+	points := make([]common.ForecastPoint, 0, len(result.Forecast))
+	for _, f := range result.Forecast {
+		points = append(points, common.ForecastPoint{
+			PointTime: f.PointTime,
+			Value:     f.Value * lbsTogramms / 1000,
+		})
+	}
+
+	forecast := common.NewForecast(
+		zone,
+		result.GeneratedAt,
+		common.GramsPerKilowattHour,
+		common.MarginalOperatingRate,
+		common.Marginal,
+		forecastResolution,
+		points,
+	)
+
+	return forecast, nil
+}
+
+// GetHistory returns the historical MOER of a balancing authority between
+// start and end, oldest first.
+func (p *WattTimeProvider) GetHistory(ctx context.Context, zone string, start time.Time, end time.Time) ([]common.Reading, error) {
+	if p.apiVersion == V3 {
+		return p.getHistoryV3(ctx, zone, start, end)
+	}
+
+	params := url.Values{}
+	params.Add("ba", zone)
+	params.Add("starttime", start.UTC().Format(time.RFC3339))
+	params.Add("endtime", end.UTC().Format(time.RFC3339))
+
+	var result HistoryResult
+	err := p.get(ctx, "/data", params, &result)
 	if err != nil {
-		return common.NoValue, err
+		return nil, err
 	}
 
-	moer, err := strconv.ParseFloat(result.MOER, 64)
+	readings := make([]common.Reading, 0, len(result))
+	for _, d := range result {
+		reading := common.Reading{
+			Zone:          d.BalancingAuthority,
+			Value:         d.Value * lbsTogramms / 1000,
+			Unit:          common.GramsPerKilowattHour,
+			SignalType:    common.MarginalOperatingRate,
+			EmissionsType: common.Marginal,
+			PointTime:     d.PointTime,
+		}
+
+		if d.Frequency != nil {
+			reading.Frequency = time.Duration(*d.Frequency) * time.Second
+		}
+
+		readings = append(readings, reading)
+	}
+
+	// the v2 API returns the latest data point first
+	slices.SortFunc(readings, func(a, b common.Reading) int {
+		return a.PointTime.Compare(b.PointTime)
+	})
+
+	return readings, nil
+}
+
+// GetZones returns the balancing authorities the account has access to.
+func (p *WattTimeProvider) GetZones(ctx context.Context) ([]common.Zone, error) {
+	access, err := p.getAccess(ctx)
 	if err != nil {
-		return common.NoValue, nil
+		return nil, err
 	}
 
-	carbonIntensity := moer * lbsTogramms / 1000
-	return carbonIntensity, nil
+	return slices.Clone(access.zones), nil
 }
 
-func (p *WattTimeProvider) GetForecast(ctx context.Context, zone string) (map[time.Time]float64, error) {
+// GetZoneFromLocation returns the balancing authority the given location lies in.
+func (p *WattTimeProvider) GetZoneFromLocation(ctx context.Context, latitude float64, longitude float64) (*common.Zone, error) {
+	if p.apiVersion == V3 {
+		return p.getZoneFromLocationV3(ctx, latitude, longitude)
+	}
+
+	params := url.Values{}
+	params.Add("latitude", strconv.FormatFloat(latitude, 'f', -1, 64))
+	params.Add("longitude", strconv.FormatFloat(longitude, 'f', -1, 64))
+
+	var result RegionFromLocationResult
+	err := p.get(ctx, "/ba-from-loc", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.Zone{Name: result.BalancingAuthority, DisplayName: result.Name}, nil
+}
+
+// get sends an authorized GET request to the given path of the WattTime API
+// and decodes the JSON response into result. An expired or revoked token is
+// replaced by logging in again, once.
+func (p *WattTimeProvider) get(ctx context.Context, path string, params url.Values, result any) error {
+	err := p.doGet(ctx, path, params, result)
+	if !errors.Is(err, errUnauthorized) {
+		return err
+	}
+
+	tokens.invalidate(p.uid)
+	if err := p.login(ctx); err != nil {
+		return err
+	}
+
+	return p.doGet(ctx, path, params, result)
+}
+
+func (p *WattTimeProvider) doGet(ctx context.Context, path string, params url.Values, result any) error {
 	requestUrl := common.ResolveAbsoluteUriReference(
 		p.baseUrl,
-		&url.URL{Path: wattTimeApiVersionUrlPath},
-		&url.URL{Path: "/forecast"},
+		&url.URL{Path: "/" + string(p.apiVersion)},
+		&url.URL{Path: path},
 	)
-	params := url.Values{}
-	params.Add("ba", zone)
 	requestUrl.RawQuery = params.Encode()
 
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestUrl.String(), nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
+	p.mu.RLock()
 	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", p.token))
+	p.mu.RUnlock()
 	response, err := p.client.Do(request)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusOK {
-		apierr, msg, err := p.unwrapHttpResponseErrorPayload(response)
-		if err != nil {
-			return nil, errors.New(response.Status)
-		}
+	if response.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("%w: %s", errUnauthorized, p.responseError(response))
+	}
 
-		return nil, errors.New(fmt.Sprintf("%s; %s: %s", response.Status, apierr, msg))
+	if response.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("%w: %s", errForbidden, p.responseError(response))
 	}
 
-	bytes, err := io.ReadAll(response.Body)
-	if err != nil {
-		return nil, err
+	if response.StatusCode != http.StatusOK {
+		return p.responseError(response)
 	}
 
-	var result ForecastResult
-	err = json.Unmarshal(bytes, &result)
+	bytes, err := io.ReadAll(response.Body)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	forecasts := make(map[time.Time]float64)
-	for _, f := range result.Forecast {
-		forecasts[f.PointTime] = f.Value
+	return json.Unmarshal(bytes, result)
+}
+
+// responseError returns the error of a failed request, including the error
+// payload of the WattTime API if there is one.
+func (p *WattTimeProvider) responseError(response *http.Response) error {
+	apierr, msg, err := p.unwrapHttpResponseErrorPayload(response)
+	if err != nil {
+		return errors.New(response.Status)
 	}
 
-	return forecasts, nil
+	return errors.New(fmt.Sprintf("%s; %s: %s", response.Status, apierr, msg))
 }
 
 func (p *WattTimeProvider) unwrapHttpResponseErrorPayload(response *http.Response) (apiError string, message string, err error) {
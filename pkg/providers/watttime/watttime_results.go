@@ -19,3 +19,63 @@ type ForecastResult struct {
 		Ba        string    `json:"ba"`
 	} `json:"forecast"`
 }
+
+type HistoryResult []struct {
+	BalancingAuthority string    `json:"ba"`
+	DataType           string    `json:"datatype"`
+	Frequency          *int      `json:"frequency"`
+	Market             string    `json:"market"`
+	PointTime          time.Time `json:"point_time"`
+	Value              float64   `json:"value"`
+	Version            string    `json:"version"`
+}
+
+type RegionsResult []struct {
+	BalancingAuthority string `json:"ba"`
+	Name               string `json:"name"`
+	Access             string `json:"access"`
+	DataType           string `json:"datatype"`
+}
+
+type RegionFromLocationResult struct {
+	ID                 int    `json:"id"`
+	BalancingAuthority string `json:"abbrev"`
+	Name               string `json:"name"`
+}
+
+// V3Meta describes the data of a v3 response
+type V3Meta struct {
+	Region                 string    `json:"region"`
+	SignalType             string    `json:"signal_type"`
+	Units                  string    `json:"units"`
+	DataPointPeriodSeconds int       `json:"data_point_period_seconds"`
+	GeneratedAt            time.Time `json:"generated_at"`
+}
+
+type V3DataResult struct {
+	Data []struct {
+		PointTime time.Time `json:"point_time"`
+		Value     float64   `json:"value"`
+	} `json:"data"`
+	Meta V3Meta `json:"meta"`
+}
+
+type V3RegionFromLocationResult struct {
+	Region         string `json:"region"`
+	RegionFullName string `json:"region_full_name"`
+	SignalType     string `json:"signal_type"`
+}
+
+type V3AccessResult struct {
+	SignalTypes []struct {
+		SignalType string `json:"signal_type"`
+		Regions    []struct {
+			Region                 string `json:"region"`
+			RegionFullName         string `json:"region_full_name"`
+			DataPointPeriodSeconds int    `json:"data_point_period_seconds"`
+			Endpoints              []struct {
+				Endpoint string `json:"endpoint"`
+			} `json:"endpoints"`
+		} `json:"regions"`
+	} `json:"signal_types"`
+}
@@ -0,0 +1,158 @@
+package watttime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// newTestProvider returns a provider of the given API version that sends its
+// requests to the handler, with a valid token. The provider is of its own
+// WattTime object, named after the test.
+func newTestProvider(t *testing.T, apiVersion APIVersion, handler http.Handler) *WattTimeProvider {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	uid := types.UID(t.Name())
+	t.Cleanup(func() {
+		tokens.invalidate(uid)
+		accesses.invalidate(uid)
+	})
+
+	baseUrl, _ := url.Parse(server.URL)
+	return &WattTimeProvider{
+		apiVersion: apiVersion,
+		baseUrl:    baseUrl,
+		uid:        uid,
+		token:      "valid",
+		client:     server.Client(),
+	}
+}
+
+func TestGetCapabilitiesV3(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoints []string
+		forecast  bool
+	}{
+		{name: "forecast", endpoints: []string{"v3/signal-index", "v3/forecast", "v3/historical"}, forecast: true},
+		{name: "index only", endpoints: []string{"v3/signal-index"}, forecast: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			mux := http.NewServeMux()
+			mux.HandleFunc("/v3/my-access", func(w http.ResponseWriter, r *http.Request) {
+				requests++
+
+				endpoints := make([]map[string]string, 0, len(tt.endpoints))
+				for _, endpoint := range tt.endpoints {
+					endpoints = append(endpoints, map[string]string{"endpoint": endpoint})
+				}
+
+				json.NewEncoder(w).Encode(map[string]any{"signal_types": []any{
+					map[string]any{"signal_type": "co2_moer", "regions": []any{map[string]any{
+						"region":                    "CAISO_NORTH",
+						"region_full_name":          "California ISO Northern",
+						"data_point_period_seconds": 300,
+						"endpoints":                 endpoints,
+					}}},
+					map[string]any{"signal_type": "health_damage", "regions": []any{map[string]any{"region": "PJM_DC"}}},
+				}})
+			})
+			provider := newTestProvider(t, V3, mux)
+
+			capabilities, err := provider.GetCapabilities(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if capabilities.Forecast != tt.forecast || (capabilities.ForecastHorizon > 0) != tt.forecast {
+				t.Errorf("forecast = %t within %s, want %t", capabilities.Forecast, capabilities.ForecastHorizon, tt.forecast)
+			}
+
+			zones, err := provider.GetZones(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(zones) != 1 || zones[0].Name != "CAISO_NORTH" {
+				t.Errorf("zones = %v, want only CAISO_NORTH", zones)
+			}
+
+			if requests != 1 {
+				t.Errorf("my-access was requested %d times, want the access to be cached", requests)
+			}
+		})
+	}
+}
+
+func TestAccessIsSharedByProvidersOfTheObject(t *testing.T) {
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v3/my-access", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"signal_types":[{"signal_type":"co2_moer","regions":[{"region":"CAISO_NORTH"}]}]}`))
+	})
+	provider := newTestProvider(t, V3, mux)
+	rebuilt := newTestProvider(t, V3, mux)
+	rotated := newTestProvider(t, V3, mux)
+	rotated.credentials = credentialsFingerprint(rotated.baseUrl.String(), "user", "rotated")
+
+	for _, p := range []*WattTimeProvider{provider, rebuilt, rotated} {
+		if _, err := p.GetZones(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if requests != 2 {
+		t.Errorf("my-access was requested %d times, want once per credentials", requests)
+	}
+}
+
+func TestGetCapabilitiesV2(t *testing.T) {
+	forecastStatus := http.StatusForbidden
+	forecastRequests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/ba-access", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"ba":"CAISO_NORTH","name":"California ISO Northern","access":"true","datatype":"MOER"}]`))
+	})
+	mux.HandleFunc("/v2/forecast", func(w http.ResponseWriter, r *http.Request) {
+		forecastRequests++
+		w.WriteHeader(forecastStatus)
+		w.Write([]byte(`{"generated_at":"2023-06-01T12:00:00Z","forecast":[]}`))
+	})
+	provider := newTestProvider(t, V2, mux)
+
+	capabilities, err := provider.GetCapabilities(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !capabilities.Forecast {
+		t.Errorf("forecast = false, want forecasts to be assumed")
+	}
+	if forecastRequests != 0 {
+		t.Errorf("forecast was requested %d times, want access to be discovered without one", forecastRequests)
+	}
+
+	if _, err := provider.GetForecast(context.Background(), "CAISO_NORTH"); !errors.Is(err, errForbidden) {
+		t.Fatalf("err = %v, want forbidden", err)
+	}
+
+	capabilities, err = provider.GetCapabilities(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if capabilities.Forecast {
+		t.Errorf("forecast = true, want false once a forecast was forbidden")
+	}
+}
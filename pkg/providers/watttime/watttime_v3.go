@@ -0,0 +1,188 @@
+package watttime
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/rekuberate-io/carbon/pkg/common"
+)
+
+const (
+	// v3SignalType is the signal the v3 endpoints are queried for
+	v3SignalType = "co2_moer"
+	// v3UnitsPounds are the units of the MOER values of v3 in the US
+	v3UnitsPounds = "lbs_co2_per_mwh"
+	// v3ForecastEndpoint is how my-access names the forecast endpoint
+	v3ForecastEndpoint = "v3/forecast"
+)
+
+// getCurrentV3 returns the current MOER, which v3 serves as the first point of
+// a forecast without horizon, together with its percentile from the signal
+// index.
+func (p *WattTimeProvider) getCurrentV3(ctx context.Context, zone string) (*common.Reading, error) {
+	params := v3Params(zone)
+	params.Add("horizon_hours", "0")
+
+	var result V3DataResult
+	err := p.get(ctx, "/forecast", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Data) == 0 {
+		return nil, fmt.Errorf("no current data for region '%s'", zone)
+	}
+
+	reading := &common.Reading{
+		Zone:          zone,
+		Value:         v3ToGramsPerKilowattHour(result.Data[0].Value, result.Meta.Units),
+		Unit:          common.GramsPerKilowattHour,
+		SignalType:    common.MarginalOperatingRate,
+		EmissionsType: common.Marginal,
+		PointTime:     result.Data[0].PointTime,
+		UpdatedAt:     result.Meta.GeneratedAt,
+		Frequency:     time.Duration(result.Meta.DataPointPeriodSeconds) * time.Second,
+	}
+
+	// the percentile is informational, so a reading is still returned
+	// without it
+	var index V3DataResult
+	if err := p.get(ctx, "/signal-index", v3Params(zone), &index); err == nil && len(index.Data) > 0 {
+		percent := index.Data[0].Value
+		reading.Percent = &percent
+	}
+
+	return reading, nil
+}
+
+func (p *WattTimeProvider) getForecastV3(ctx context.Context, zone string) (*common.Forecast, error) {
+	params := v3Params(zone)
+	params.Add("horizon_hours", strconv.Itoa(int(forecastHorizon.Hours())))
+
+	var result V3DataResult
+	err := p.get(ctx, "/forecast", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]common.ForecastPoint, 0, len(result.Data))
+	for _, d := range result.Data {
+		points = append(points, common.ForecastPoint{
+			PointTime: d.PointTime,
+			Value:     v3ToGramsPerKilowattHour(d.Value, result.Meta.Units),
+		})
+	}
+
+	resolution := forecastResolution
+	if result.Meta.DataPointPeriodSeconds > 0 {
+		resolution = time.Duration(result.Meta.DataPointPeriodSeconds) * time.Second
+	}
+
+	forecast := common.NewForecast(
+		zone,
+		result.Meta.GeneratedAt,
+		common.GramsPerKilowattHour,
+		common.MarginalOperatingRate,
+		common.Marginal,
+		resolution,
+		points,
+	)
+
+	return forecast, nil
+}
+
+// getHistoryV3 returns the historical MOER of a region between start and end.
+func (p *WattTimeProvider) getHistoryV3(ctx context.Context, zone string, start time.Time, end time.Time) ([]common.Reading, error) {
+	params := v3Params(zone)
+	params.Add("start", start.UTC().Format(time.RFC3339))
+	params.Add("end", end.UTC().Format(time.RFC3339))
+
+	var result V3DataResult
+	err := p.get(ctx, "/historical", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	readings := make([]common.Reading, 0, len(result.Data))
+	for _, d := range result.Data {
+		readings = append(readings, common.Reading{
+			Zone:          zone,
+			Value:         v3ToGramsPerKilowattHour(d.Value, result.Meta.Units),
+			Unit:          common.GramsPerKilowattHour,
+			SignalType:    common.MarginalOperatingRate,
+			EmissionsType: common.Marginal,
+			PointTime:     d.PointTime,
+			Frequency:     time.Duration(result.Meta.DataPointPeriodSeconds) * time.Second,
+		})
+	}
+
+	return readings, nil
+}
+
+// getAccessV3 returns the regions the account has access to for the MOER
+// signal, and whether the plan includes the forecast of any of them.
+func (p *WattTimeProvider) getAccessV3(ctx context.Context) (*access, error) {
+	var result V3AccessResult
+	err := p.get(ctx, "/my-access", url.Values{}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	access := &access{zones: make([]common.Zone, 0), resolution: forecastResolution}
+	for _, signalType := range result.SignalTypes {
+		if signalType.SignalType != v3SignalType {
+			continue
+		}
+
+		for _, region := range signalType.Regions {
+			access.zones = append(access.zones, common.Zone{Name: region.Region, DisplayName: region.RegionFullName})
+			if region.DataPointPeriodSeconds > 0 {
+				access.resolution = time.Duration(region.DataPointPeriodSeconds) * time.Second
+			}
+
+			for _, endpoint := range region.Endpoints {
+				if endpoint.Endpoint == v3ForecastEndpoint {
+					access.forecast = true
+				}
+			}
+		}
+	}
+
+	return access, nil
+}
+
+func (p *WattTimeProvider) getZoneFromLocationV3(ctx context.Context, latitude float64, longitude float64) (*common.Zone, error) {
+	params := url.Values{}
+	params.Add("latitude", strconv.FormatFloat(latitude, 'f', -1, 64))
+	params.Add("longitude", strconv.FormatFloat(longitude, 'f', -1, 64))
+	params.Add("signal_type", v3SignalType)
+
+	var result V3RegionFromLocationResult
+	err := p.get(ctx, "/region-from-loc", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.Zone{Name: result.Region, DisplayName: result.RegionFullName}, nil
+}
+
+func v3Params(zone string) url.Values {
+	params := url.Values{}
+	params.Add("region", zone)
+	params.Add("signal_type", v3SignalType)
+
+	return params
+}
+
+// v3ToGramsPerKilowattHour converts a MOER value of the given units to
+// gCO2/kWh; values of other units are already metric.
+func v3ToGramsPerKilowattHour(value float64, units string) float64 {
+	if units == v3UnitsPounds {
+		return value * lbsTogramms / 1000
+	}
+
+	return value
+}
@@ -0,0 +1,202 @@
+package watttime
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	"github.com/rekuberate-io/carbon/pkg/providers/providertest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// v3Handler serves the MOER of CAISO_NORTH in lbs/MWh the way the v3 API
+// does, failing the test on requests missing the region or signal type.
+func v3Handler(t *testing.T, handle func(w http.ResponseWriter, r *http.Request)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("signal_type") != v3SignalType {
+			t.Errorf("%s: signal_type = %q, want %s", r.URL.Path, query.Get("signal_type"), v3SignalType)
+		}
+		if r.URL.Path != "/v3/region-from-loc" && query.Get("region") != "CAISO_NORTH" {
+			t.Errorf("%s: region = %q, want CAISO_NORTH", r.URL.Path, query.Get("region"))
+		}
+		if r.Header.Get("Authorization") != "Bearer valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		handle(w, r)
+	}
+}
+
+func writeV3Data(w http.ResponseWriter, values ...float64) {
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	data := make([]map[string]any, 0, len(values))
+	for i, value := range values {
+		data = append(data, map[string]any{"point_time": start.Add(time.Duration(i) * 5 * time.Minute), "value": value})
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"data": data,
+		"meta": map[string]any{
+			"region":                    "CAISO_NORTH",
+			"signal_type":               v3SignalType,
+			"units":                     v3UnitsPounds,
+			"data_point_period_seconds": 300,
+			"generated_at":              start,
+		},
+	})
+}
+
+func TestGetCurrentV3(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v3/forecast", v3Handler(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("horizon_hours") != "0" {
+			t.Errorf("horizon_hours = %q, want 0", r.URL.Query().Get("horizon_hours"))
+		}
+		writeV3Data(w, 1000)
+	}))
+	mux.HandleFunc("/v3/signal-index", v3Handler(t, func(w http.ResponseWriter, r *http.Request) {
+		writeV3Data(w, 42)
+	}))
+	provider := newTestProvider(t, V3, mux)
+
+	reading, err := provider.GetCurrent(context.Background(), "CAISO_NORTH")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !providertest.Near(reading.Value, 453.59) || reading.Frequency != 5*time.Minute {
+		t.Errorf("reading = %v every %s, want 453.59 every 5m", reading.Value, reading.Frequency)
+	}
+	if reading.Percent == nil || *reading.Percent != 42 {
+		t.Errorf("percent = %v, want 42", reading.Percent)
+	}
+}
+
+func TestGetForecastV3(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v3/forecast", v3Handler(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("horizon_hours") != "24" {
+			t.Errorf("horizon_hours = %q, want 24", r.URL.Query().Get("horizon_hours"))
+		}
+		writeV3Data(w, 1000, 500, 0)
+	}))
+	provider := newTestProvider(t, V3, mux)
+
+	forecast, err := provider.GetForecast(context.Background(), "CAISO_NORTH")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(forecast.Points) != 3 || !providertest.Near(forecast.Points[1].Value, 226.80) {
+		t.Errorf("forecast = %v, want 3 points with 226.80 second", forecast.Points)
+	}
+	if forecast.Resolution != 5*time.Minute {
+		t.Errorf("resolution = %s, want 5m", forecast.Resolution)
+	}
+}
+
+func TestGetHistoryV3(t *testing.T) {
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(10 * time.Minute)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v3/historical", v3Handler(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("start") != start.Format(time.RFC3339) || r.URL.Query().Get("end") != end.Format(time.RFC3339) {
+			t.Errorf("window = %s to %s, want %s to %s", r.URL.Query().Get("start"), r.URL.Query().Get("end"), start, end)
+		}
+		writeV3Data(w, 1000, 500, 0)
+	}))
+	provider := newTestProvider(t, V3, mux)
+
+	readings, err := provider.GetHistory(context.Background(), "CAISO_NORTH", start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(readings) != 3 || !readings[0].PointTime.Equal(start) || readings[2].Value != 0 {
+		t.Errorf("history = %v, want 3 readings from %s", readings, start)
+	}
+}
+
+func TestGetZoneFromLocationV3(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v3/region-from-loc", v3Handler(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("latitude") != "37.7749" || r.URL.Query().Get("longitude") != "-122.4194" {
+			t.Errorf("location = %s,%s, want 37.7749,-122.4194", r.URL.Query().Get("latitude"), r.URL.Query().Get("longitude"))
+		}
+		w.Write([]byte(`{"region":"CAISO_NORTH","region_full_name":"California ISO Northern","signal_type":"co2_moer"}`))
+	}))
+	provider := newTestProvider(t, V3, mux)
+
+	zone, err := provider.GetZoneFromLocation(context.Background(), 37.7749, -122.4194)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if zone.Name != "CAISO_NORTH" {
+		t.Errorf("zone = %s, want CAISO_NORTH", zone.Name)
+	}
+}
+
+func TestNewProviderSelectsAPIVersion(t *testing.T) {
+	var loginPath string
+	login := func(w http.ResponseWriter, r *http.Request) {
+		loginPath = r.URL.Path
+		json.NewEncoder(w).Encode(map[string]string{"token": "valid"})
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", login)
+	mux.HandleFunc("/v2/login", login)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	original := baseUrls
+	baseUrls = map[APIVersion]string{V2: server.URL + "/", V3: server.URL + "/"}
+	t.Cleanup(func() { baseUrls = original })
+
+	tests := []struct {
+		apiVersion string
+		want       APIVersion
+		loginPath  string
+		wantErr    bool
+	}{
+		{apiVersion: "", want: V2, loginPath: "/v2/login"},
+		{apiVersion: "v2", want: V2, loginPath: "/v2/login"},
+		{apiVersion: "v3", want: V3, loginPath: "/login"},
+		{apiVersion: "v4", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		loginPath = ""
+		uid := types.UID("watttime-" + tt.apiVersion)
+		t.Cleanup(func() { tokens.invalidate(uid) })
+
+		o := carbonv1alpha1.WattTime{
+			ObjectMeta: metav1.ObjectMeta{UID: uid},
+			Spec:       carbonv1alpha1.WattTimeSpec{Username: "user", APIVersion: tt.apiVersion},
+		}
+		secret := &corev1.Secret{Data: map[string][]byte{"password": []byte("secret")}}
+
+		provider, err := NewProvider(context.Background(), o, secret)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("apiVersion %q: error = %v, wantErr %v", tt.apiVersion, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+
+		if provider.apiVersion != tt.want || loginPath != tt.loginPath {
+			t.Errorf("apiVersion %q: got %s logged in at %s, want %s at %s", tt.apiVersion, provider.apiVersion, loginPath, tt.want, tt.loginPath)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rekuberate-io/carbon/pkg/common"
+	"slices"
+)
+
+var (
+	ErrUnknownZone = errors.New("unknown zone")
+)
+
+// ValidateZone checks that the provider lists the given zone, or accepts it if
+// the provider implements ZoneValidator. It returns an error wrapping
+// ErrUnknownZone if it does not, or any error that occurred while listing the
+// zones of the provider.
+func ValidateZone(ctx context.Context, provider Provider, zone string) error {
+	if validator, ok := provider.(ZoneValidator); ok {
+		valid, err := validator.IsValidZone(ctx, zone)
+		if err != nil {
+			return fmt.Errorf("unable to validate zone: %w", err)
+		}
+
+		if !valid {
+			return fmt.Errorf("%w '%s'", ErrUnknownZone, zone)
+		}
+
+		return nil
+	}
+
+	zones, err := provider.GetZones(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list zones: %w", err)
+	}
+
+	if !slices.ContainsFunc(zones, func(z common.Zone) bool { return z.Name == zone }) {
+		return fmt.Errorf("%w '%s'", ErrUnknownZone, zone)
+	}
+
+	return nil
+}
+
+// ZoneValidator is implemented by providers whose zones can not be listed
+// exhaustively, e.g. because they accept postcodes.
+type ZoneValidator interface {
+	IsValidZone(ctx context.Context, zone string) (bool, error)
+}
+
+// ZoneResolver is implemented by providers that can resolve the zone a
+// geographic location lies in.
+type ZoneResolver interface {
+	GetZoneFromLocation(ctx context.Context, latitude float64, longitude float64) (*common.Zone, error)
+}
@@ -0,0 +1,98 @@
+package regions
+
+import (
+	"github.com/rekuberate-io/carbon/pkg/common"
+)
+
+// zones are the zones of a region in the naming schemes of the providers; an
+// empty zone means the region is not covered in that scheme.
+type zones struct {
+	electricityMaps    string
+	balancingAuthority string
+}
+
+// defaults maps the regions of AWS, GCP and Azure to the grid zones of their
+// data centers. The region names of the clouds do not overlap.
+var defaults = map[string]zones{
+	// AWS
+	"us-east-1":      {electricityMaps: "US-MIDA-PJM", balancingAuthority: "PJM_DC"},
+	"us-east-2":      {electricityMaps: "US-MIDA-PJM"},
+	"us-west-1":      {electricityMaps: "US-CAL-CISO", balancingAuthority: "CAISO_NORTH"},
+	"us-west-2":      {electricityMaps: "US-NW-BPAT", balancingAuthority: "BPA"},
+	"ca-central-1":   {electricityMaps: "CA-QC"},
+	"sa-east-1":      {electricityMaps: "BR-CS"},
+	"eu-west-1":      {electricityMaps: "IE"},
+	"eu-west-2":      {electricityMaps: "GB"},
+	"eu-west-3":      {electricityMaps: "FR"},
+	"eu-central-1":   {electricityMaps: "DE"},
+	"eu-central-2":   {electricityMaps: "CH"},
+	"eu-north-1":     {electricityMaps: "SE-SE3"},
+	"eu-south-1":     {electricityMaps: "IT-NO"},
+	"eu-south-2":     {electricityMaps: "ES"},
+	"ap-northeast-1": {electricityMaps: "JP-TK"},
+	"ap-northeast-2": {electricityMaps: "KR"},
+	"ap-southeast-1": {electricityMaps: "SG"},
+	"ap-southeast-2": {electricityMaps: "AU-NSW"},
+	"ap-south-1":     {electricityMaps: "IN-WE"},
+
+	// GCP
+	"us-central1":             {electricityMaps: "US-MIDW-MISO"},
+	"us-east1":                {electricityMaps: "US-SE-SOCO"},
+	"us-east4":                {electricityMaps: "US-MIDA-PJM", balancingAuthority: "PJM_DC"},
+	"us-west1":                {electricityMaps: "US-NW-BPAT", balancingAuthority: "BPA"},
+	"northamerica-northeast1": {electricityMaps: "CA-QC"},
+	"southamerica-east1":      {electricityMaps: "BR-CS"},
+	"europe-west1":            {electricityMaps: "BE"},
+	"europe-west2":            {electricityMaps: "GB"},
+	"europe-west3":            {electricityMaps: "DE"},
+	"europe-west4":            {electricityMaps: "NL"},
+	"europe-west6":            {electricityMaps: "CH"},
+	"europe-west9":            {electricityMaps: "FR"},
+	"europe-north1":           {electricityMaps: "FI"},
+	"europe-central2":         {electricityMaps: "PL"},
+	"asia-northeast1":         {electricityMaps: "JP-TK"},
+	"asia-southeast1":         {electricityMaps: "SG"},
+	"australia-southeast1":    {electricityMaps: "AU-NSW"},
+
+	// Azure
+	"eastus":             {electricityMaps: "US-MIDA-PJM", balancingAuthority: "PJM_DC"},
+	"eastus2":            {electricityMaps: "US-MIDA-PJM", balancingAuthority: "PJM_DC"},
+	"centralus":          {electricityMaps: "US-MIDW-MISO"},
+	"westus":             {electricityMaps: "US-CAL-CISO", balancingAuthority: "CAISO_NORTH"},
+	"canadacentral":      {electricityMaps: "CA-ON"},
+	"brazilsouth":        {electricityMaps: "BR-CS"},
+	"northeurope":        {electricityMaps: "IE"},
+	"westeurope":         {electricityMaps: "NL"},
+	"uksouth":            {electricityMaps: "GB"},
+	"francecentral":      {electricityMaps: "FR"},
+	"germanywestcentral": {electricityMaps: "DE"},
+	"swedencentral":      {electricityMaps: "SE-SE3"},
+	"switzerlandnorth":   {electricityMaps: "CH"},
+	"norwayeast":         {electricityMaps: "NO-NO1"},
+	"polandcentral":      {electricityMaps: "PL"},
+	"italynorth":         {electricityMaps: "IT-NO"},
+	"japaneast":          {electricityMaps: "JP-TK"},
+	"australiaeast":      {electricityMaps: "AU-NSW"},
+}
+
+// DefaultZone returns the built-in zone of a cloud region in the given naming
+// scheme, and whether there is one. Providers that accept any zone are given
+// ElectricityMaps zones; National Grid only serves the regions in Great Britain.
+func DefaultZone(region string, naming common.ZoneNamingScheme) (string, bool) {
+	z, ok := defaults[region]
+	if !ok {
+		return "", false
+	}
+
+	zone := z.electricityMaps
+	switch naming {
+	case common.BalancingAuthority:
+		zone = z.balancingAuthority
+	case common.NationalGridZone:
+		if zone != "GB" {
+			zone = ""
+		}
+	}
+
+	return zone, zone != ""
+}
@@ -0,0 +1,42 @@
+package regions
+
+import (
+	"testing"
+
+	"github.com/rekuberate-io/carbon/pkg/common"
+)
+
+func TestDefaultZone(t *testing.T) {
+	tests := []struct {
+		name   string
+		region string
+		naming common.ZoneNamingScheme
+		zone   string
+		ok     bool
+	}{
+		{name: "any zone", region: "eu-west-1", naming: common.AnyZone, zone: "IE", ok: true},
+		{name: "electricitymaps zone", region: "europe-west4", naming: common.ElectricityMapsZone, zone: "NL", ok: true},
+		{name: "balancing authority", region: "westus", naming: common.BalancingAuthority, zone: "CAISO_NORTH", ok: true},
+		{name: "region without balancing authority", region: "eu-west-1", naming: common.BalancingAuthority},
+		{name: "national grid", region: "uksouth", naming: common.NationalGridZone, zone: "GB", ok: true},
+		{name: "region outside of great britain", region: "eu-west-1", naming: common.NationalGridZone},
+		{name: "unknown region", region: "eu-west_1", naming: common.AnyZone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zone, ok := DefaultZone(tt.region, tt.naming)
+			if zone != tt.zone || ok != tt.ok {
+				t.Errorf("DefaultZone(%s, %s) = %q, %t, want %q, %t", tt.region, tt.naming, zone, ok, tt.zone, tt.ok)
+			}
+		})
+	}
+}
+
+func TestDefaultsCoverEveryRegion(t *testing.T) {
+	for region, z := range defaults {
+		if z.electricityMaps == "" {
+			t.Errorf("region %s has no ElectricityMaps zone", region)
+		}
+	}
+}
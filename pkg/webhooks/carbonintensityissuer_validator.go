@@ -0,0 +1,116 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	carbonv1beta1 "github.com/rekuberate-io/carbon/api/v1beta1"
+	"github.com/rekuberate-io/carbon/pkg/providers"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"reflect"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"time"
+)
+
+const (
+	zoneValidationTimeout = 5 * time.Second
+)
+
+var (
+	issuerlog = logf.Log.WithName("carbonintensityissuer-webhook")
+)
+
+// CarbonIntensityIssuerValidator validates CarbonIntensityIssuers against the
+// providers they reference.
+type CarbonIntensityIssuerValidator struct {
+	Client client.Client
+}
+
+func (v *CarbonIntensityIssuerValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	issuer, ok := obj.(*carbonv1beta1.CarbonIntensityIssuer)
+	if !ok {
+		return fmt.Errorf("expected a CarbonIntensityIssuer but got %T", obj)
+	}
+
+	return v.validate(ctx, issuer)
+}
+
+func (v *CarbonIntensityIssuerValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	oldIssuer, ok := oldObj.(*carbonv1beta1.CarbonIntensityIssuer)
+	if !ok {
+		return fmt.Errorf("expected a CarbonIntensityIssuer but got %T", oldObj)
+	}
+
+	issuer, ok := newObj.(*carbonv1beta1.CarbonIntensityIssuer)
+	if !ok {
+		return fmt.Errorf("expected a CarbonIntensityIssuer but got %T", newObj)
+	}
+
+	// the provider and the zone are only checked again, if the issuer points
+	// somewhere else
+	if oldIssuer.Spec.Zone == issuer.Spec.Zone && reflect.DeepEqual(oldIssuer.Spec.ProviderRef, issuer.Spec.ProviderRef) {
+		return nil
+	}
+
+	return v.validate(ctx, issuer)
+}
+
+func (v *CarbonIntensityIssuerValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func (v *CarbonIntensityIssuerValidator) validate(ctx context.Context, issuer *carbonv1beta1.CarbonIntensityIssuer) error {
+	var allErrs field.ErrorList
+
+	providerRefPath := field.NewPath("spec", "providerRef")
+	if err := validateProviderRef(ctx, v.Client, issuer.Spec.ProviderRef, issuer.Namespace, providerRefPath); err != nil {
+		// the zone can not be checked without a provider
+		allErrs = append(allErrs, err)
+	} else if err := v.validateZone(ctx, issuer); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(issuer.GroupVersionKind().GroupKind(), issuer.Name, allErrs)
+}
+
+// validateZone rejects zones the referenced provider does not list. Any other
+// failure is not conclusive and is left to the reconciler to report. Zones
+// resolved from a location are left to the reconciler as well.
+func (v *CarbonIntensityIssuerValidator) validateZone(ctx context.Context, issuer *carbonv1beta1.CarbonIntensityIssuer) *field.Error {
+	if issuer.Spec.ProviderRef == nil || issuer.Spec.Zone == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, zoneValidationTimeout)
+	defer cancel()
+
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(issuer)}
+	provider, err := providers.GetProvider(ctx, req, v.Client, issuer.Spec.ProviderRef.DeepCopy())
+	if err != nil {
+		issuerlog.Info("skipping zone validation, unable to get provider", "issuer", req.String(), "error", err.Error())
+		return nil
+	}
+
+	err = providers.ValidateZone(ctx, provider, issuer.Spec.Zone)
+	if errors.Is(err, providers.ErrUnknownZone) {
+		return field.Invalid(
+			field.NewPath("spec", "zone"),
+			issuer.Spec.Zone,
+			fmt.Sprintf("zone is not served by provider '%s' (%s)", issuer.Spec.ProviderRef.Name, issuer.Spec.ProviderRef.Kind),
+		)
+	}
+
+	if err != nil {
+		issuerlog.Info("skipping zone validation, unable to list zones", "issuer", req.String(), "error", err.Error())
+	}
+
+	return nil
+}
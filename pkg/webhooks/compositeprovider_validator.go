@@ -0,0 +1,85 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	"github.com/rekuberate-io/carbon/pkg/providers"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CompositeProviderValidator validates that the members of CompositeProvider
+// objects reference existing provider objects, other than the composite itself.
+type CompositeProviderValidator struct {
+	Client client.Client
+}
+
+func (v *CompositeProviderValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	o, ok := obj.(*carbonv1alpha1.CompositeProvider)
+	if !ok {
+		return fmt.Errorf("expected a CompositeProvider but got %T", obj)
+	}
+
+	return v.validate(ctx, o)
+}
+
+func (v *CompositeProviderValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	o, ok := newObj.(*carbonv1alpha1.CompositeProvider)
+	if !ok {
+		return fmt.Errorf("expected a CompositeProvider but got %T", newObj)
+	}
+
+	return v.validate(ctx, o)
+}
+
+func (v *CompositeProviderValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func (v *CompositeProviderValidator) validate(ctx context.Context, o *carbonv1alpha1.CompositeProvider) error {
+	var allErrs field.ErrorList
+	membersPath := field.NewPath("spec", "members")
+
+	seen := map[string]bool{}
+	for i, member := range o.Spec.Members {
+		memberPath := membersPath.Index(i)
+		providerRefPath := memberPath.Child("providerRef")
+		if member.ProviderRef == nil {
+			allErrs = append(allErrs, field.Required(providerRefPath, "a provider reference is required"))
+			continue
+		}
+
+		namespace := member.ProviderRef.Namespace
+		if namespace == "" {
+			namespace = o.Namespace
+		}
+
+		// checked first, as the composite does not exist yet when it is created
+		if strings.EqualFold(member.ProviderRef.Kind, string(providers.Composite)) && namespace == o.Namespace && member.ProviderRef.Name == o.Name {
+			allErrs = append(allErrs, field.Invalid(providerRefPath, member.ProviderRef.Name, "must not reference the composite provider itself"))
+			continue
+		}
+
+		if err := validateProviderRef(ctx, v.Client, member.ProviderRef, o.Namespace, providerRefPath); err != nil {
+			allErrs = append(allErrs, err)
+			continue
+		}
+
+		key := strings.ToLower(fmt.Sprintf("%s/%s/%s/%s", member.ProviderRef.Kind, namespace, member.ProviderRef.Name, member.Zone))
+		if seen[key] {
+			allErrs = append(allErrs, field.Duplicate(memberPath, member.ProviderRef.Name))
+		}
+		seen[key] = true
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(carbonv1alpha1.GroupVersion.WithKind("CompositeProvider").GroupKind(), o.Name, allErrs)
+}
@@ -0,0 +1,93 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	"github.com/rekuberate-io/carbon/pkg/providers/generichttp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"net/url"
+	"strings"
+)
+
+// GenericHTTPValidator validates the URLs and extraction expressions of
+// GenericHTTP objects.
+type GenericHTTPValidator struct{}
+
+func (v *GenericHTTPValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	o, ok := obj.(*carbonv1alpha1.GenericHTTP)
+	if !ok {
+		return fmt.Errorf("expected a GenericHTTP but got %T", obj)
+	}
+
+	return v.validate(o)
+}
+
+func (v *GenericHTTPValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	o, ok := newObj.(*carbonv1alpha1.GenericHTTP)
+	if !ok {
+		return fmt.Errorf("expected a GenericHTTP but got %T", newObj)
+	}
+
+	return v.validate(o)
+}
+
+func (v *GenericHTTPValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func (v *GenericHTTPValidator) validate(o *carbonv1alpha1.GenericHTTP) error {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	currentPath := specPath.Child("current")
+	allErrs = append(allErrs, validateURL(o.Spec.Current.URL, currentPath.Child("url"))...)
+	allErrs = append(allErrs, validateExpression(&o.Spec.Current.Value, currentPath.Child("value"))...)
+	allErrs = append(allErrs, validateExpression(o.Spec.Current.Timestamp, currentPath.Child("timestamp"))...)
+
+	if forecast := o.Spec.Forecast; forecast != nil {
+		forecastPath := specPath.Child("forecast")
+		allErrs = append(allErrs, validateURL(forecast.URL, forecastPath.Child("url"))...)
+		allErrs = append(allErrs, validateExpression(&forecast.Points, forecastPath.Child("points"))...)
+		allErrs = append(allErrs, validateExpression(&forecast.Value, forecastPath.Child("value"))...)
+		allErrs = append(allErrs, validateExpression(&forecast.Timestamp, forecastPath.Child("timestamp"))...)
+	}
+
+	if auth := o.Spec.Auth; auth != nil {
+		authPath := specPath.Child("auth")
+		allErrs = append(allErrs, carbonv1alpha1.ValidateSecretReference(auth.SecretRef, authPath.Child("secretRef"))...)
+		if len(auth.Headers) == 0 && len(auth.QueryParameters) == 0 {
+			allErrs = append(allErrs, field.Required(authPath, "at least one header or query parameter is required"))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(carbonv1alpha1.GroupVersion.WithKind("GenericHTTP").GroupKind(), o.Name, allErrs)
+}
+
+// validateURL checks that the URL is absolute once the zone is filled in.
+func validateURL(rawUrl string, fldPath *field.Path) field.ErrorList {
+	u, err := url.Parse(strings.ReplaceAll(rawUrl, "{zone}", "zone"))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return field.ErrorList{field.Invalid(fldPath, rawUrl, "must be an absolute URL")}
+	}
+
+	return nil
+}
+
+func validateExpression(e *carbonv1alpha1.GenericHTTPExpression, fldPath *field.Path) field.ErrorList {
+	if e == nil {
+		return nil
+	}
+
+	if err := generichttp.CompileExpression(*e); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, e, err.Error())}
+	}
+
+	return nil
+}
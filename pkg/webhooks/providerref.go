@@ -0,0 +1,50 @@
+package webhooks
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rekuberate-io/carbon/pkg/providers"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	providerreflog = logf.Log.WithName("providerref-webhook")
+)
+
+// validateProviderRef checks that a provider reference is of a supported kind
+// and points to an existing provider object. Failures to look the provider
+// object up are not conclusive and are left to the reconciler to report.
+func validateProviderRef(ctx context.Context, c client.Client, providerRef *v1.ObjectReference, namespace string, fldPath *field.Path) *field.Error {
+	if providerRef == nil {
+		return field.Required(fldPath, "a provider reference is required")
+	}
+
+	if !providers.IsSupported(providerRef.Kind) {
+		supported := make([]string, 0, len(providers.GetSupportedProviders()))
+		for _, p := range providers.GetSupportedProviders() {
+			supported = append(supported, string(p))
+		}
+
+		return field.NotSupported(fldPath.Child("kind"), strings.ToLower(providerRef.Kind), supported)
+	}
+
+	if providerRef.Name == "" {
+		return field.Required(fldPath.Child("name"), "the name of the provider is required")
+	}
+
+	_, err := providers.GetProviderObject(ctx, c, providerRef, namespace)
+	if apierrors.IsNotFound(err) {
+		return field.NotFound(fldPath, providerRef.Kind+"/"+providerRef.Name)
+	}
+
+	if err != nil {
+		providerreflog.Info("skipping provider validation, unable to get provider", "provider", providerRef.Name, "error", err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidateProviderRef(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := carbonv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&carbonv1alpha1.Simulator{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "simulator-sample"}},
+	).Build()
+
+	tests := []struct {
+		name        string
+		providerRef *v1.ObjectReference
+		namespace   string
+		want        field.ErrorType
+	}{
+		{name: "existing provider", providerRef: &v1.ObjectReference{Kind: "Simulator", Name: "simulator-sample"}, namespace: "default"},
+		{name: "lower case kind", providerRef: &v1.ObjectReference{Kind: "simulator", Name: "simulator-sample"}, namespace: "default"},
+		{name: "provider of another namespace", providerRef: &v1.ObjectReference{Kind: "Simulator", Namespace: "default", Name: "simulator-sample"}, namespace: "carbon"},
+		{name: "missing reference", providerRef: nil, want: field.ErrorTypeRequired},
+		{name: "unsupported kind", providerRef: &v1.ObjectReference{Kind: "Tomorrow", Name: "tomorrow-sample"}, namespace: "default", want: field.ErrorTypeNotSupported},
+		{name: "missing name", providerRef: &v1.ObjectReference{Kind: "Simulator"}, namespace: "default", want: field.ErrorTypeRequired},
+		{name: "missing provider", providerRef: &v1.ObjectReference{Kind: "Simulator", Name: "simulator-sample"}, namespace: "carbon", want: field.ErrorTypeNotFound},
+		{name: "missing provider of another kind", providerRef: &v1.ObjectReference{Kind: "WattTime", Name: "simulator-sample"}, namespace: "default", want: field.ErrorTypeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateProviderRef(context.Background(), c, tt.providerRef, tt.namespace, field.NewPath("spec", "providerRef"))
+
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("error = %v, want none", err)
+			case tt.want != "" && (err == nil || err.Type != tt.want):
+				t.Errorf("error = %v, want %s", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	carbonv1alpha1 "github.com/rekuberate-io/carbon/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RegionZoneMappingValidator validates RegionZoneMappings against the
+// providers they reference.
+type RegionZoneMappingValidator struct {
+	Client client.Client
+}
+
+func (v *RegionZoneMappingValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	mapping, ok := obj.(*carbonv1alpha1.RegionZoneMapping)
+	if !ok {
+		return fmt.Errorf("expected a RegionZoneMapping but got %T", obj)
+	}
+
+	return v.validate(ctx, mapping)
+}
+
+func (v *RegionZoneMappingValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	oldMapping, ok := oldObj.(*carbonv1alpha1.RegionZoneMapping)
+	if !ok {
+		return fmt.Errorf("expected a RegionZoneMapping but got %T", oldObj)
+	}
+
+	mapping, ok := newObj.(*carbonv1alpha1.RegionZoneMapping)
+	if !ok {
+		return fmt.Errorf("expected a RegionZoneMapping but got %T", newObj)
+	}
+
+	if reflect.DeepEqual(oldMapping.Spec.ProviderRef, mapping.Spec.ProviderRef) {
+		return nil
+	}
+
+	return v.validate(ctx, mapping)
+}
+
+func (v *RegionZoneMappingValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func (v *RegionZoneMappingValidator) validate(ctx context.Context, mapping *carbonv1alpha1.RegionZoneMapping) error {
+	var allErrs field.ErrorList
+
+	providerRefPath := field.NewPath("spec", "providerRef")
+	if err := validateProviderRef(ctx, v.Client, mapping.Spec.ProviderRef, mapping.Namespace, providerRefPath); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(mapping.GroupVersionKind().GroupKind(), mapping.Name, allErrs)
+}